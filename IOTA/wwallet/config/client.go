@@ -0,0 +1,177 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wasp/packages/nodeclient"
+	"wasp/tools/wwallet/journal"
+	"wasp/tools/wwallet/txinfo"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// walletClient wraps the goshimmer client so that every transaction posted by
+// the CLI, including SC requests posted through scclient, is journaled and
+// tracked as pending until it is confirmed, or only shown with --dry-run.
+type walletClient struct {
+	nodeclient.NodeClient
+	// reserve makes the client hide the outputs reserved by other wwallet
+	// invocations, and reserve the inputs of the transactions it posts. It
+	// is used by the SC clients, which select their inputs themselves.
+	reserve bool
+}
+
+// SpendingClient is the goshimmer client for code that builds its own
+// transactions, such as the SC clients: it only offers unreserved outputs
+// and reserves the inputs it posts.
+func SpendingClient() nodeclient.NodeClient {
+	c := GoshimmerClient().(*walletClient)
+	c.reserve = true
+	return c
+}
+
+func (c *walletClient) GetConfirmedAccountOutputs(addr *address.Address) (map[transaction.OutputID][]*balance.Balance, error) {
+	outs, err := c.NodeClient.GetConfirmedAccountOutputs(addr)
+	if err != nil || !c.reserve {
+		return outs, err
+	}
+	unlock := LockWallet()
+	defer unlock()
+	return UnreservedOutputs(outs)
+}
+
+// ErrDryRun is returned instead of posting a transaction with --dry-run.
+var ErrDryRun = errors.New("dry run: transaction not posted")
+
+func (c *walletClient) PostTransaction(tx *transaction.Transaction) error {
+	if DryRun {
+		txinfo.Dump(tx)
+		return ErrDryRun
+	}
+	if c.reserve {
+		unlock := LockWallet()
+		err := ReserveInputs(tx)
+		unlock()
+		if err != nil {
+			return err
+		}
+	}
+	if err := c.NodeClient.PostTransaction(tx); err != nil {
+		ReleaseInputs(tx)
+		return err
+	}
+	KeepReservation(tx)
+
+	unlock := LockWallet()
+	defer unlock()
+
+	isNew, err := journal.AddPending(PendingPath(), tx)
+	if err != nil {
+		warn(err)
+	}
+	if isNew {
+		record(journal.NewEntry(tx))
+	}
+	return nil
+}
+
+func (c *walletClient) PostAndWaitForConfirmation(tx *transaction.Transaction) error {
+	if err := c.PostTransaction(tx); err != nil {
+		return err
+	}
+	return c.WaitForConfirmation(tx.ID())
+}
+
+func (c *walletClient) WaitForConfirmation(txid transaction.ID) error {
+	if err := c.NodeClient.WaitForConfirmation(txid); err != nil {
+		return err
+	}
+	SetTransactionState(txid, journal.StateConfirmed)
+	return nil
+}
+
+// SetTransactionState records the final state of a transaction and removes it
+// from the pending set.
+func SetTransactionState(txid transaction.ID, state string) {
+	unlock := LockWallet()
+	defer unlock()
+
+	if err := journal.RemovePending(PendingPath(), txid); err != nil {
+		warn(err)
+	}
+	record(journal.StateUpdate(txid, state))
+}
+
+// RecordBurn notes in the journal that a posted transaction burned amount
+// tokens of color, see BurnedSupply.
+func RecordBurn(txid transaction.ID, color balance.Color, amount int64) {
+	unlock := LockWallet()
+	defer unlock()
+	record(journal.BurnUpdate(txid, color, amount))
+}
+
+// BurnedSupply returns the amount of color burned with `wallet burn` in the
+// selected profile. Burns made by other wallets are not known.
+func BurnedSupply(color balance.Color) int64 {
+	entries, err := journal.Read(JournalPath())
+	if err != nil {
+		warn(err)
+		return 0
+	}
+	return journal.Burned(entries, color)
+}
+
+func JournalPath() string {
+	return configPathWithExt(".journal")
+}
+
+func PendingPath() string {
+	return configPathWithExt(".pending")
+}
+
+// TokenCachePath is where TokenRegistry lookups are cached.
+func TokenCachePath() string {
+	return configPathWithExt(".tokens")
+}
+
+// AddressCachePath is where the addresses derived from an encrypted seed are
+// cached.
+func AddressCachePath() string {
+	return configPathWithExt(".addresses")
+}
+
+// RuntimeDir is where wwallet keeps its sockets: $XDG_RUNTIME_DIR/wwallet, or
+// a directory next to the config file.
+func RuntimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "wwallet")
+	}
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ".run"
+}
+
+// configPathWithExt returns the path of a state file next to the config
+// file. The state of other profiles than the default one is kept apart,
+// e.g. wwallet.testnet.journal, since it belongs to another network.
+func configPathWithExt(ext string) string {
+	base := strings.TrimSuffix(configPath, filepath.Ext(configPath))
+	if profile := Profile(); profile != defaultProfile {
+		base += "." + profile
+	}
+	return base + ext
+}
+
+func record(e *journal.Entry) {
+	if err := journal.Append(JournalPath(), e); err != nil {
+		warn(err)
+	}
+}
+
+func warn(err error) {
+	fmt.Fprintf(os.Stderr, "warning: could not update the transaction journal: %v\n", err)
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var configSubcmds = map[string]func([]string){
+	"doctor": doctorCmd,
+	"show":   showCmd,
+}
+
+func configCmd(args []string) {
+	if len(args) < 1 {
+		configUsage()
+	}
+	subcmd, ok := configSubcmds[args[0]]
+	if !ok {
+		configUsage()
+	}
+	subcmd(args[1:])
+}
+
+func configUsage() {
+	cmdNames := make([]string, 0)
+	for k := range configSubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s config [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"wasp/packages/nodeclient"
 	"wasp/packages/nodeclient/goshimmer"
@@ -18,6 +19,7 @@ var Verbose bool
 var WaitForCompletion bool
 var Utxodb bool
 var SCAlias string
+var DryRun bool
 
 const (
 	hostKindApi     = "api"
@@ -27,6 +29,10 @@ const (
 
 func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet) {
 	commands["set"] = setCmd
+	commands["contacts"] = contactsCmd
+	commands["profile"] = profileCmd
+	commands["config"] = configCmd
+	commands["node"] = nodeCmd
 
 	fs := pflag.NewFlagSet("config", pflag.ExitOnError)
 	fs.StringVarP(&configPath, "config", "c", "wwallet.json", "path to wwallet.json")
@@ -34,28 +40,40 @@ func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet) {
 	fs.BoolVarP(&WaitForCompletion, "wait", "w", false, "wait for confirmation")
 	fs.BoolVarP(&Utxodb, "utxodb", "u", false, "use utxodb")
 	fs.StringVarP(&SCAlias, "sc", "s", "", "smart contract alias")
+	fs.StringVar(&profileFlag, "profile", "", "network profile (default: $WWALLET_PROFILE or the one selected with `profile use`)")
+	fs.StringArrayVar(&setFlags, "set", nil, "override a config key for this invocation: --set key=value (repeatable); WWALLET_<KEY> env vars, with . replaced by _ and _ by __, do the same; network keys apply to the selected profile")
+	fs.BoolVar(&showEffective, "effective", false, "config show: show the merged configuration and where each value comes from")
+	fs.BoolVar(&DryRun, "dry-run", false, "build transactions and show them without posting")
 	flags.AddFlagSet(fs)
 }
 
 func setCmd(args []string) {
 	if len(args) != 2 {
 		fmt.Printf("Usage: %s set <key> <value>\n", os.Args[0])
+		fmt.Printf("Network keys (%s) are set in the selected profile\n", strings.Join(networkKeys, ", "))
 		os.Exit(1)
 	}
-	Set(args[0], args[1])
+	Set(profileKey(strings.ToLower(args[0])), args[1])
 }
 
 func Read() {
 	viper.SetConfigFile(configPath)
-	_ = viper.ReadInConfig()
+	if err := viper.ReadInConfig(); err == nil {
+		migrate()
+	}
+	applyOverrides()
+	warnUnknownKeys()
+	checkProfile()
 }
 
+const goshimmerApiKey = "goshimmer." + hostKindApi
+
 func GoshimmerApiConfigVar() string {
-	return "goshimmer." + hostKindApi
+	return ProfileConfigVar(goshimmerApiKey)
 }
 
 func GoshimmerApi() string {
-	r := viper.GetString(GoshimmerApiConfigVar())
+	r := Current().SelectedNetwork().Goshimmer.Api
 	if r != "" {
 		return r
 	}
@@ -63,73 +81,49 @@ func GoshimmerApi() string {
 }
 
 func GoshimmerClient() nodeclient.NodeClient {
-	if Utxodb {
-		return testutil.NewGoshimmerUtxodbClient(GoshimmerApi())
+	if UseUtxodb() {
+		return &walletClient{testutil.NewGoshimmerUtxodbClient(GoshimmerApi())}
 	}
-	return goshimmer.NewGoshimmerClient(GoshimmerApi())
+	return &walletClient{goshimmer.NewGoshimmerClient(GoshimmerApi())}
 }
 
 func WaspApi() string {
-	r := viper.GetString("wasp." + hostKindApi)
+	r := Current().SelectedNetwork().Wasp.Api
 	if r != "" {
 		return r
 	}
-	return committeeHost(hostKindApi, 0)
+	return nodeHost(hostKindApi, DefaultNodeName(0))
 }
 
 func WaspNanomsg() string {
-	r := viper.GetString("wasp." + hostKindNanomsg)
+	r := Current().SelectedNetwork().Wasp.Nanomsg
 	if r != "" {
 		return r
 	}
-	return committeeHost(hostKindNanomsg, 0)
+	return nodeHost(hostKindNanomsg, DefaultNodeName(0))
 }
 
-func CommitteeApi(indices []int) []string {
-	return committee(hostKindApi, indices)
+// CommitteeApi returns the API hosts of the named nodes.
+func CommitteeApi(nodes []string) []string {
+	return committee(hostKindApi, nodes)
 }
 
-func CommitteePeering(indices []int) []string {
-	return committee(hostKindPeering, indices)
+func CommitteePeering(nodes []string) []string {
+	return committee(hostKindPeering, nodes)
 }
 
-func CommitteeNanomsg(indices []int) []string {
-	return committee(hostKindNanomsg, indices)
+func CommitteeNanomsg(nodes []string) []string {
+	return committee(hostKindNanomsg, nodes)
 }
 
-func committee(kind string, indices []int) []string {
+func committee(kind string, nodes []string) []string {
 	hosts := make([]string, 0)
-	for _, i := range indices {
-		hosts = append(hosts, committeeHost(kind, i))
+	for _, name := range nodes {
+		hosts = append(hosts, nodeHost(kind, name))
 	}
 	return hosts
 }
 
-func committeeConfigVar(kind string, i int) string {
-	return fmt.Sprintf("wasp.%d.%s", i, kind)
-}
-
-func CommitteeApiConfigVar(i int) string {
-	return committeeConfigVar(hostKindApi, i)
-}
-
-func CommitteePeeringConfigVar(i int) string {
-	return committeeConfigVar(hostKindPeering, i)
-}
-
-func CommitteeNanomsgConfigVar(i int) string {
-	return committeeConfigVar(hostKindNanomsg, i)
-}
-
-func committeeHost(kind string, i int) string {
-	r := viper.GetString(committeeConfigVar(kind, i))
-	if r != "" {
-		return r
-	}
-	defaultPort := defaultWaspPort(kind, i)
-	return fmt.Sprintf("127.0.0.1:%d", defaultPort)
-}
-
 func defaultWaspPort(kind string, i int) int {
 	switch kind {
 	case hostKindNanomsg:
@@ -144,15 +138,21 @@ func defaultWaspPort(kind string, i int) int {
 
 func Set(key string, value interface{}) {
 	viper.Set(key, value)
-	check(viper.WriteConfig())
+	check(WriteConfig())
+}
+
+// SCConfigVar returns the config key of a setting of the SC with the given
+// alias, in the selected profile.
+func SCConfigVar(scAlias string, key string) string {
+	return ProfileConfigVar("sc." + scAlias + "." + key)
 }
 
 func SetSCAddress(scAlias string, address string) {
-	Set("sc."+scAlias+".address", address)
+	Set(SCConfigVar(scAlias, "address"), address)
 }
 
 func TrySCAddress(scAlias string) *address.Address {
-	b58 := viper.GetString("sc." + scAlias + ".address")
+	b58 := Current().SelectedNetwork().SCSection(scAlias).Address
 	if len(b58) == 0 {
 		return nil
 	}
@@ -164,15 +164,26 @@ func TrySCAddress(scAlias string) *address.Address {
 func GetSCAddress(scAlias string) *address.Address {
 	address := TrySCAddress(scAlias)
 	if address == nil {
-		check(fmt.Errorf("call `%s set sc.%s.address` or `%s --sc=%s sc admin deploy` first",
-			os.Args[0], scAlias, os.Args[0], scAlias))
+		check(fmt.Errorf("call `%s set %s` or `%s --sc=%s sc admin deploy` first",
+			os.Args[0], SCConfigVar(scAlias, "address"), os.Args[0], scAlias))
 	}
 	return address
 }
 
-func check(err error) {
+// Check exits if err is not nil: successfully for ErrDryRun, which stops a
+// command before posting, and with an error otherwise. The check functions
+// of the CLI packages delegate to it.
+func Check(err error) {
+	if err == ErrDryRun {
+		fmt.Printf("%s\n", err)
+		Exit(0)
+	}
 	if err != nil {
 		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
+		Exit(1)
 	}
 }
+
+func check(err error) {
+	Check(err)
+}
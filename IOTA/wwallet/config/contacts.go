@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+)
+
+const contactsConfigVar = "contacts"
+
+var contactsSubcmds = map[string]func([]string){
+	"add":    contactsAddCmd,
+	"list":   contactsListCmd,
+	"remove": contactsRemoveCmd,
+}
+
+func contactsCmd(args []string) {
+	if len(args) < 1 {
+		contactsUsage()
+	}
+	subcmd, ok := contactsSubcmds[args[0]]
+	if !ok {
+		contactsUsage()
+	}
+	subcmd(args[1:])
+}
+
+func contactsUsage() {
+	cmdNames := make([]string, 0)
+	for k := range contactsSubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s contacts [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func contactsAddCmd(args []string) {
+	if len(args) != 2 {
+		fmt.Printf("Usage: %s contacts add <alias> <address>\n", os.Args[0])
+		os.Exit(1)
+	}
+	alias := strings.ToLower(args[0])
+	if strings.Contains(alias, ".") {
+		check(fmt.Errorf("alias cannot contain '.'"))
+	}
+	addr, err := address.FromBase58(args[1])
+	check(err)
+	Set(contactsConfigVar+"."+alias, addr.String())
+}
+
+func contactsListCmd(args []string) {
+	contacts := Contacts()
+	aliases := make([]string, 0, len(contacts))
+	for alias := range contacts {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		fmt.Printf("%s: %s\n", alias, contacts[alias])
+	}
+}
+
+func contactsRemoveCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s contacts remove <alias>\n", os.Args[0])
+		os.Exit(1)
+	}
+	alias := strings.ToLower(args[0])
+	if _, ok := Contacts()[alias]; !ok {
+		check(fmt.Errorf("no contact named %s", alias))
+	}
+	Unset(contactsConfigVar + "." + alias)
+}
+
+// Contacts returns the address book, indexed by alias.
+func Contacts() map[string]string {
+	ret := make(map[string]string)
+	for alias, v := range Current().Contacts {
+		if v != "" {
+			ret[alias] = v
+		}
+	}
+	return ret
+}
+
+// ResolveAddress accepts a contact alias, a smart contract alias or a base58
+// address.
+func ResolveAddress(s string) (address.Address, error) {
+	if b58, ok := Contacts()[strings.ToLower(s)]; ok {
+		return address.FromBase58(b58)
+	}
+	if addr := TrySCAddress(strings.ToLower(s)); addr != nil {
+		return *addr, nil
+	}
+	return address.FromBase58(s)
+}
+
+func MustResolveAddress(s string) address.Address {
+	addr, err := ResolveAddress(s)
+	check(err)
+	return addr
+}
+
+// IsKnownAddress tells whether the address is in the address book or is the
+// address of a configured smart contract.
+func IsKnownAddress(addr address.Address) bool {
+	for _, b58 := range Contacts() {
+		if b58 == addr.String() {
+			return true
+		}
+	}
+	for alias := range Current().SelectedNetwork().SC {
+		if scAddr := TrySCAddress(alias); scAddr != nil && *scAddr == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func WarnIfUnknownAddress(addr address.Address) {
+	if !IsKnownAddress(addr) {
+		fmt.Fprintf(os.Stderr, "warning: %s is not in the address book\n", addr)
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/viper"
+)
+
+const probeTimeout = 3 * time.Second
+
+type endpoint struct {
+	name string
+	host string
+}
+
+type probeResult struct {
+	endpoint
+	latency time.Duration
+	err     error
+}
+
+func doctorCmd(args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s config doctor\n", os.Args[0])
+		Exit(1)
+	}
+
+	fmt.Printf("Configuration %s, profile %s\n\n", configPath, Profile())
+
+	problems, warnings := validate()
+	for _, p := range problems {
+		fmt.Printf("  problem: %s\n", p)
+	}
+	for _, w := range warnings {
+		fmt.Printf("  warning: %s\n", w)
+	}
+	if len(problems) == 0 {
+		fmt.Printf("  no configuration problems found\n")
+	}
+	fmt.Println()
+
+	results := probe(endpoints())
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ENDPOINT\tHOST\tLATENCY\tSTATUS\n")
+	failed := len(problems) > 0
+	for _, r := range results {
+		status, latency := "ok", r.latency.Round(time.Millisecond).String()
+		if r.err != nil {
+			status, latency = r.err.Error(), "-"
+			failed = true
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.name, r.host, latency, status)
+	}
+	check(w.Flush())
+
+	if failed {
+		Exit(1)
+	}
+}
+
+// validate checks the selected profile and the address book, and returns a
+// description of each problem found, and warnings about settings that work
+// but may not be intended.
+func validate() ([]string, []string) {
+	problems := make([]string, 0)
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+	warnings := make([]string, 0)
+
+	if _, err := os.Stat(configPath); err != nil {
+		addProblem("%v", err)
+	} else if err := readConfigFile(); err != nil {
+		addProblem("%s: %v", configPath, err)
+	}
+
+	for _, key := range unknownKeys() {
+		addProblem("unknown key %s", key)
+	}
+
+	network := Current().SelectedNetwork()
+	checkHost(ProfileConfigVar(goshimmerApiKey), network.Goshimmer.Api, addProblem)
+	checkHost(ProfileConfigVar("wasp."+hostKindApi), network.Wasp.Api, addProblem)
+	checkHost(ProfileConfigVar("wasp."+hostKindNanomsg), network.Wasp.Nanomsg, addProblem)
+	for name, node := range network.Nodes {
+		for _, kind := range []string{hostKindApi, hostKindPeering, hostKindNanomsg} {
+			checkHost(NodeConfigVar(name, kind), node.host(kind), addProblem)
+		}
+		if pubKey := node.PubKey; pubKey != "" {
+			if _, err := base58.Decode(pubKey); err != nil {
+				addProblem("%s: invalid public key: %v", NodeConfigVar(name, nodePubKey), err)
+			}
+		}
+	}
+	for alias, sc := range network.SC {
+		if sc.Address != "" {
+			if _, err := address.FromBase58(sc.Address); err != nil {
+				addProblem("%s: invalid address %q: %v", SCConfigVar(alias, "address"), sc.Address, err)
+			}
+		}
+		for _, name := range sc.Committee {
+			switch {
+			case nodeExists(name):
+			case nodeKnown(name):
+				// probed along with the registered nodes
+				warnings = append(warnings, fmt.Sprintf("%s: node %s is not registered under %s, it is expected on the default local ports",
+					SCConfigVar(alias, "committee"), name, ProfileConfigVar(nodesConfigVar)))
+			default:
+				addProblem("%s: node %s is not registered under %s", SCConfigVar(alias, "committee"), name, ProfileConfigVar(nodesConfigVar))
+			}
+		}
+		if len(sc.Committee) > 0 && sc.Quorum > len(sc.Committee) {
+			addProblem("%s: quorum %d exceeds the committee size %d", SCConfigVar(alias, "quorum"), sc.Quorum, len(sc.Committee))
+		}
+	}
+
+	if Current().Wallet.Seed != "" {
+		warnings = append(warnings, fmt.Sprintf("wallet.seed is stored in plaintext, run `%s wallet lock` to encrypt it", os.Args[0]))
+	}
+	for name, id := range Current().Identities {
+		if id.Seed != "" {
+			warnings = append(warnings, fmt.Sprintf("the seed of identity %s is stored in plaintext, run `%s --as %s wallet lock` to encrypt it", name, os.Args[0], name))
+		}
+	}
+
+	for alias, b58 := range Contacts() {
+		if _, err := address.FromBase58(b58); err != nil {
+			addProblem("%s.%s: invalid address %q: %v", contactsConfigVar, alias, b58, err)
+		}
+	}
+	sort.Strings(problems)
+	sort.Strings(warnings)
+	return problems, warnings
+}
+
+// checkHost checks the host set under the config key.
+func checkHost(key string, host string, addProblem func(string, ...interface{})) {
+	if host == "" {
+		return
+	}
+	_, port, err := net.SplitHostPort(host)
+	if err != nil {
+		addProblem("%s: %v", key, err)
+		return
+	}
+	if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+		addProblem("%s: invalid port %q", key, port)
+	}
+}
+
+func endpoints() []endpoint {
+	ret := []endpoint{
+		{"goshimmer api", GoshimmerApi()},
+		{"wasp api", WaspApi()},
+		{"wasp nanomsg", WaspNanomsg()},
+	}
+	for _, name := range usedNodes() {
+		ret = append(ret,
+			endpoint{name + " api", nodeHost(hostKindApi, name)},
+			endpoint{name + " peering", nodeHost(hostKindPeering, name)},
+			endpoint{name + " nanomsg", nodeHost(hostKindNanomsg, name)},
+		)
+	}
+	return ret
+}
+
+// usedNodes returns the registered nodes, and the unregistered ones with a
+// default name that are in a committee, which use the default local ports.
+func usedNodes() []string {
+	names := Nodes()
+	for _, sc := range Current().SelectedNetwork().SC {
+		for _, name := range sc.Committee {
+			if !nodeExists(name) && nodeKnown(name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	ret := make([]string, 0, len(names))
+	for i, name := range names {
+		if i == 0 || names[i-1] != name {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
+// probe connects to all endpoints concurrently.
+func probe(endpoints []endpoint) []*probeResult {
+	results := make([]*probeResult, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			conn, err := net.DialTimeout("tcp", e.host, probeTimeout)
+			if err == nil {
+				_ = conn.Close()
+			}
+			results[i] = &probeResult{endpoint: e, latency: time.Since(start), err: err}
+		}(i, e)
+	}
+	wg.Wait()
+	return results
+}
+
+func readConfigFile() error {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	return v.ReadInConfig()
+}
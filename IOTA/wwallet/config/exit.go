@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"sync"
+)
+
+var exitMutex sync.Mutex
+var exitHooks = map[int]func(){}
+var nextExitHook int
+
+// AtExit registers f to be run when the command fails through Exit, e.g.
+// to undo a reservation. The returned function unregisters it.
+func AtExit(f func()) func() {
+	exitMutex.Lock()
+	defer exitMutex.Unlock()
+
+	id := nextExitHook
+	nextExitHook++
+	exitHooks[id] = f
+	return func() {
+		exitMutex.Lock()
+		defer exitMutex.Unlock()
+		delete(exitHooks, id)
+	}
+}
+
+// Exit runs the registered hooks and terminates the process. The check
+// functions of the CLI packages exit through it.
+func Exit(code int) {
+	exitMutex.Lock()
+	hooks := exitHooks
+	exitHooks = map[int]func(){}
+	exitMutex.Unlock()
+
+	for _, f := range hooks {
+		f()
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+)
+
+// CurrentVersion is the version of the config layout written by this
+// wwallet. Bump it together with a new entry in migrations.
+const CurrentVersion = 1
+
+// migrations[i] migrates the settings from version i to version i+1. Version
+// 0 is the unversioned layout of the original wwallet.json.
+var migrations = []func(settings map[string]interface{}){
+	migrateV0,
+}
+
+// migrate brings the config file to the current layout, keeping a copy of
+// the original next to it.
+func migrate() {
+	version := viper.GetInt("version")
+	if version > CurrentVersion {
+		check(fmt.Errorf("%s has version %d, but this wwallet only understands up to version %d",
+			configPath, version, CurrentVersion))
+	}
+	if version == CurrentVersion {
+		return
+	}
+
+	original, err := ioutil.ReadFile(configPath)
+	check(err)
+	backup := fmt.Sprintf("%s.v%d.bak", configPath, version)
+	check(ioutil.WriteFile(backup, original, 0600))
+
+	settings := viper.AllSettings()
+	migrateSettings(settings, version)
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	check(v.MergeConfigMap(settings))
+	check(v.WriteConfig())
+	check(viper.ReadInConfig())
+
+	fmt.Fprintf(os.Stderr, "Migrated %s from version %d to %d (backup in %s)\n", configPath, version, CurrentVersion, backup)
+}
+
+// migrateSettings applies the migrations from the given version to the
+// current one.
+func migrateSettings(settings map[string]interface{}, version int) {
+	for v := version; v < CurrentVersion; v++ {
+		migrations[v](settings)
+	}
+	settings["version"] = CurrentVersion
+}
+
+// migrateV0 moves the nodes from wasp.<i> to nodes.node<i>, and replaces the
+// indices in the SC committees with the node names.
+func migrateV0(settings map[string]interface{}) {
+	migrateNodes(settings)
+	if profiles, ok := settings[profilesConfigVar].(map[string]interface{}); ok {
+		for _, p := range profiles {
+			if network, ok := p.(map[string]interface{}); ok {
+				migrateNodes(network)
+			}
+		}
+	}
+}
+
+func migrateNodes(network map[string]interface{}) {
+	nodes, ok := network[nodesConfigVar].(map[string]interface{})
+	if !ok {
+		nodes = make(map[string]interface{})
+	}
+	if wasp, ok := network["wasp"].(map[string]interface{}); ok {
+		for k, v := range wasp {
+			if i, err := strconv.Atoi(k); err == nil {
+				nodes[DefaultNodeName(i)] = v
+				delete(wasp, k)
+			}
+		}
+	}
+	if len(nodes) > 0 {
+		network[nodesConfigVar] = nodes
+	}
+
+	scs, ok := network["sc"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, v := range scs {
+		sc, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		indices, ok := sc["committee"].([]interface{})
+		if !ok {
+			continue
+		}
+		names := make([]string, 0, len(indices))
+		for _, index := range indices {
+			i, err := cast.ToIntE(index)
+			check(err)
+			names = append(names, DefaultNodeName(i))
+		}
+		sc["committee"] = names
+	}
+}
+
+// warnUnknownKeys reports the settings that are not part of the layout,
+// which are usually typos.
+func warnUnknownKeys() {
+	for _, key := range unknownKeys() {
+		fmt.Fprintf(os.Stderr, "warning: unknown config key %s\n", key)
+	}
+}
+
+func unknownKeys() []string {
+	_, unused, err := DecodeFile(viper.AllSettings())
+	if err != nil {
+		return []string{err.Error()}
+	}
+	return unused
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// v0Config is the unversioned layout, with the nodes under wasp.<i> and the
+// committees as node indices.
+const v0Config = `{
+  "goshimmer": {"api": "127.0.0.1:8080"},
+  "sc": {
+    "fr": {"address": "nCCTdXn9E5TvwH1tvva88A27Xm3Dj3biJYLCFzhHLZ4C", "committee": [0, 1, 2], "quorum": 2}
+  },
+  "wallet": {"seed": "DDjAvXvhNVFHuea5i3UAVTfkH42NxWMoHgnFeBJn53uy"},
+  "wasp": {
+    "0": {"api": "127.0.0.1:9090", "nanomsg": "127.0.0.1:5550", "peering": "127.0.0.1:4000"},
+    "1": {"api": "127.0.0.1:9091", "nanomsg": "127.0.0.1:5551", "peering": "127.0.0.1:4001"},
+    "2": {"api": "127.0.0.1:9092", "nanomsg": "127.0.0.1:5552", "peering": "127.0.0.1:4002"}
+  }
+}`
+
+func readTestConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "wwallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "wwallet.json")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Reset()
+	invalidate()
+	configPath = path
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestMigrateFromV0(t *testing.T) {
+	path := readTestConfig(t, v0Config)
+	migrate()
+
+	if _, err := os.Stat(path + ".v0.bak"); err != nil {
+		t.Fatalf("no backup of the original file: %v", err)
+	}
+	backup, err := ioutil.ReadFile(path + ".v0.bak")
+	if err != nil || string(backup) != v0Config {
+		t.Fatalf("backup differs from the original file")
+	}
+
+	// read back what was written
+	viper.Reset()
+	invalidate()
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if v := viper.GetInt("version"); v != CurrentVersion {
+		t.Fatalf("version = %d, want %d", v, CurrentVersion)
+	}
+	if keys := unknownKeys(); len(keys) != 0 {
+		t.Fatalf("unknown keys after migration: %v", keys)
+	}
+
+	f := Current()
+	if got, want := f.SC["fr"].Committee, []string{"node0", "node1", "node2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("committee = %v, want %v", got, want)
+	}
+	if got := f.SC["fr"].Quorum; got != 2 {
+		t.Fatalf("quorum = %d, want 2", got)
+	}
+	if got := f.Nodes["node1"].Peering; got != "127.0.0.1:4001" {
+		t.Fatalf("node1 peering = %s", got)
+	}
+	if got := f.Wallet.Seed; got != "DDjAvXvhNVFHuea5i3UAVTfkH42NxWMoHgnFeBJn53uy" {
+		t.Fatalf("seed = %s", got)
+	}
+	if len(f.Wasp.Api) != 0 || len(f.Wasp.Nanomsg) != 0 {
+		t.Fatalf("wasp section not emptied: %+v", f.Wasp)
+	}
+}
+
+func TestMigrateCurrentVersionIsNoop(t *testing.T) {
+	path := readTestConfig(t, fmt.Sprintf(`{"version": %d, "goshimmer": {"api": "127.0.0.1:8080"}}`, CurrentVersion))
+	migrate()
+	if _, err := os.Stat(fmt.Sprintf("%s.v%d.bak", path, CurrentVersion)); !os.IsNotExist(err) {
+		t.Fatalf("unexpected backup for a current file")
+	}
+}
+
+func TestMigrateSettingsFromV0(t *testing.T) {
+	settings := map[string]interface{}{
+		"sc": map[string]interface{}{
+			"tr": map[string]interface{}{"committee": []interface{}{float64(3)}},
+		},
+	}
+	migrateSettings(settings, 0)
+	if settings["version"] != CurrentVersion {
+		t.Fatalf("version = %v", settings["version"])
+	}
+	committee := settings["sc"].(map[string]interface{})["tr"].(map[string]interface{})["committee"]
+	if !reflect.DeepEqual(committee, []string{"node3"}) {
+		t.Fatalf("committee = %v", committee)
+	}
+}
+
+func TestMigrateNodes(t *testing.T) {
+	network := map[string]interface{}{
+		"wasp": map[string]interface{}{
+			"api": "127.0.0.1:9090",
+			"0":   map[string]interface{}{"api": "10.0.0.1:9090"},
+			"1":   map[string]interface{}{"api": "10.0.0.2:9090"},
+		},
+		"nodes": map[string]interface{}{
+			"edge": map[string]interface{}{"api": "10.0.0.9:9090"},
+		},
+		"sc": map[string]interface{}{
+			"fa": map[string]interface{}{"committee": []interface{}{0, "1", float64(2)}},
+			"fr": map[string]interface{}{"quorum": 3},
+		},
+	}
+	migrateNodes(network)
+
+	nodes := network["nodes"].(map[string]interface{})
+	for _, name := range []string{"node0", "node1", "edge"} {
+		if _, ok := nodes[name]; !ok {
+			t.Fatalf("node %s missing: %v", name, nodes)
+		}
+	}
+	if got := nodes["node1"].(map[string]interface{})["api"]; got != "10.0.0.2:9090" {
+		t.Fatalf("node1 api = %v", got)
+	}
+	wasp := network["wasp"].(map[string]interface{})
+	if !reflect.DeepEqual(wasp, map[string]interface{}{"api": "127.0.0.1:9090"}) {
+		t.Fatalf("wasp = %v, want only the api", wasp)
+	}
+	scs := network["sc"].(map[string]interface{})
+	committee := scs["fa"].(map[string]interface{})["committee"]
+	if !reflect.DeepEqual(committee, []string{"node0", "node1", "node2"}) {
+		t.Fatalf("committee = %v", committee)
+	}
+	if _, ok := scs["fr"].(map[string]interface{})["committee"]; ok {
+		t.Fatalf("committee added to fr")
+	}
+}
+
+func TestMigrateV0Profiles(t *testing.T) {
+	settings := map[string]interface{}{
+		"profiles": map[string]interface{}{
+			"testnet": map[string]interface{}{
+				"wasp": map[string]interface{}{"0": map[string]interface{}{"api": "10.0.0.1:9090"}},
+			},
+		},
+	}
+	migrateV0(settings)
+	testnet := settings["profiles"].(map[string]interface{})["testnet"].(map[string]interface{})
+	if _, ok := testnet["nodes"].(map[string]interface{})["node0"]; !ok {
+		t.Fatalf("node0 not migrated in profile: %v", testnet)
+	}
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/spf13/viper"
+)
+
+const (
+	nodesConfigVar = "nodes"
+	nodePubKey     = "pubkey"
+	nodeNamePrefix = "node"
+)
+
+// DefaultNodeName is the name of the node that used to be configured as
+// wasp.<i>. Unregistered nodes with these names use the default local ports.
+func DefaultNodeName(i int) string {
+	return nodeNamePrefix + strconv.Itoa(i)
+}
+
+func defaultNodeIndex(name string) (int, bool) {
+	if !strings.HasPrefix(name, nodeNamePrefix) {
+		return 0, false
+	}
+	i, err := strconv.Atoi(strings.TrimPrefix(name, nodeNamePrefix))
+	return i, err == nil && i >= 0
+}
+
+func NodeConfigVar(name string, key string) string {
+	return ProfileConfigVar(nodesConfigVar + "." + name + "." + key)
+}
+
+// Nodes returns the names of the nodes registered in the selected profile.
+func Nodes() []string {
+	names := make([]string, 0)
+	for name := range Current().SelectedNetwork().Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nodeKnown tells whether the node has hosts: either it is registered, or it
+// has a default node name and uses the default local ports.
+func nodeKnown(name string) bool {
+	if nodeExists(name) {
+		return true
+	}
+	_, ok := defaultNodeIndex(name)
+	return ok
+}
+
+func nodeExists(name string) bool {
+	for _, n := range Nodes() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeHost(kind string, name string) string {
+	if node, ok := Current().SelectedNetwork().Nodes[name]; ok {
+		if r := node.host(kind); r != "" {
+			return r
+		}
+	}
+	if i, ok := defaultNodeIndex(name); ok && !nodeExists(name) {
+		return fmt.Sprintf("127.0.0.1:%d", defaultWaspPort(kind, i))
+	}
+	check(fmt.Errorf("no %s host for node %s: call `%s node add` first", kind, name, os.Args[0]))
+	return ""
+}
+
+// NodePubKey returns the public key given with `node add`. It is only
+// informational: wwallet shows it but does not check it against the node.
+func NodePubKey(name string) string {
+	if node, ok := Current().SelectedNetwork().Nodes[name]; ok {
+		return node.PubKey
+	}
+	return ""
+}
+
+func (n *Node) host(kind string) string {
+	switch kind {
+	case hostKindApi:
+		return n.Api
+	case hostKindPeering:
+		return n.Peering
+	case hostKindNanomsg:
+		return n.Nanomsg
+	}
+	panic(fmt.Sprintf("no handler for kind %s", kind))
+}
+
+// ParseCommittee parses a comma-separated list of node names. Numbers are
+// accepted as the default node names, so '0,1' means 'node0,node1'.
+func ParseCommittee(s string) []string {
+	committee := make([]string, 0)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i, err := strconv.Atoi(name); err == nil {
+			name = DefaultNodeName(i)
+		}
+		committee = append(committee, name)
+	}
+	return committee
+}
+
+var nodeSubcmds = map[string]func([]string){
+	"add":    nodeAddCmd,
+	"list":   nodeListCmd,
+	"remove": nodeRemoveCmd,
+}
+
+func nodeCmd(args []string) {
+	if len(args) < 1 {
+		nodeUsage()
+	}
+	subcmd, ok := nodeSubcmds[args[0]]
+	if !ok {
+		nodeUsage()
+	}
+	subcmd(args[1:])
+}
+
+func nodeUsage() {
+	cmdNames := make([]string, 0)
+	for k := range nodeSubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s node [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func nodeAddCmd(args []string) {
+	if len(args) < 4 || len(args) > 5 {
+		fmt.Printf("Usage: %s node add <name> <api-host> <peering-host> <nanomsg-host> [<public-key>]\n", os.Args[0])
+		fmt.Printf("The public key is only recorded for reference, it is not verified.\n")
+		os.Exit(1)
+	}
+	name := strings.ToLower(args[0])
+	if strings.Contains(name, ".") || strings.Contains(name, ",") {
+		check(fmt.Errorf("node name cannot contain '.' or ','"))
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		check(fmt.Errorf("node name cannot be a number: in committees, %s stands for %s", name, nodeNamePrefix+name))
+	}
+	viper.Set(NodeConfigVar(name, hostKindApi), args[1])
+	viper.Set(NodeConfigVar(name, hostKindPeering), args[2])
+	viper.Set(NodeConfigVar(name, hostKindNanomsg), args[3])
+	if len(args) == 5 {
+		_, err := base58.Decode(args[4])
+		check(err)
+		viper.Set(NodeConfigVar(name, nodePubKey), args[4])
+	}
+	check(WriteConfig())
+}
+
+func nodeListCmd(args []string) {
+	for _, name := range Nodes() {
+		fmt.Printf("%s:\n", name)
+		fmt.Printf("  API:     %s\n", nodeHost(hostKindApi, name))
+		fmt.Printf("  Peering: %s\n", nodeHost(hostKindPeering, name))
+		fmt.Printf("  Nanomsg: %s\n", nodeHost(hostKindNanomsg, name))
+		if pubKey := NodePubKey(name); pubKey != "" {
+			fmt.Printf("  Public key: %s\n", pubKey)
+		}
+	}
+}
+
+func nodeRemoveCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s node remove <name>\n", os.Args[0])
+		os.Exit(1)
+	}
+	name := strings.ToLower(args[0])
+	if !nodeExists(name) {
+		check(fmt.Errorf("no node named %s", name))
+	}
+	for alias, sc := range Current().SelectedNetwork().SC {
+		for _, member := range sc.Committee {
+			if member == name {
+				check(fmt.Errorf("node %s is in the committee of %s", name, alias))
+			}
+		}
+	}
+	Unset(ProfileConfigVar(nodesConfigVar + "." + name))
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix of the environment variables that override config
+// keys: WWALLET_GOSHIMMER_API overrides goshimmer.api, and '__' stands for a
+// '_' in the key, so WWALLET_CONTACTS_MY__SHOP overrides contacts.my_shop.
+const EnvPrefix = "WWALLET_"
+
+// envNotConfig are the WWALLET_ variables that are not config overrides.
+var envNotConfig = map[string]bool{
+	"WWALLET_PASSPHRASE":     true,
+	"WWALLET_NEW_PASSPHRASE": true,
+	"WWALLET_MNEMONIC":       true,
+	"WWALLET_PROFILE":        true,
+}
+
+const (
+	sourceFile = "file"
+	sourceEnv  = "env"
+	sourceFlag = "flag"
+)
+
+var setFlags []string
+var showEffective bool
+
+type override struct {
+	source string
+	value  interface{}
+}
+
+// overrides are the values applied on top of the config file, indexed by key.
+var overrides = map[string]*override{}
+
+// fileSettings are the settings read from the config file, before applying
+// the overrides.
+var fileSettings map[string]interface{}
+
+// applyOverrides merges the WWALLET_ environment variables and then the
+// --set flags into the configuration.
+func applyOverrides() {
+	fileSettings = viper.AllSettings()
+
+	envVars := os.Environ()
+	sort.Strings(envVars)
+	for _, kv := range envVars {
+		i := strings.Index(kv, "=")
+		name, value := kv[:i], kv[i+1:]
+		if !strings.HasPrefix(name, EnvPrefix) || envNotConfig[name] {
+			continue
+		}
+		setOverride(profileKey(envKey(name)), value, sourceEnv+" "+name)
+	}
+
+	for _, kv := range setFlags {
+		i := strings.Index(kv, "=")
+		if i <= 0 {
+			check(fmt.Errorf("--set expects key=value, got %q", kv))
+		}
+		setOverride(profileKey(strings.ToLower(kv[:i])), kv[i+1:], sourceFlag)
+	}
+}
+
+// envKey returns the config key overridden by an environment variable.
+func envKey(name string) string {
+	parts := strings.Split(strings.TrimPrefix(name, EnvPrefix), "__")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(p, "_", ".")
+	}
+	return strings.ToLower(strings.Join(parts, "_"))
+}
+
+// profileKey maps the network keys, such as goshimmer.api, to the selected
+// profile, so that overrides apply to the network in use.
+func profileKey(key string) string {
+	first := strings.SplitN(key, ".", 2)[0]
+	for _, k := range networkKeys {
+		if first == k {
+			return ProfileConfigVar(key)
+		}
+	}
+	return key
+}
+
+func setOverride(key string, s string, source string) {
+	value := parseValue(s)
+	overrides[key] = &override{source: source, value: value}
+	check(viper.MergeConfigMap(nestedMap(key, value)))
+	invalidate()
+}
+
+// parseValue accepts JSON values (numbers, booleans, lists such as [0,1,2])
+// and falls back to a plain string.
+func parseValue(s string) interface{} {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err == nil {
+		return v
+	}
+	return s
+}
+
+func nestedMap(key string, value interface{}) map[string]interface{} {
+	path := strings.Split(key, ".")
+	m := map[string]interface{}{path[len(path)-1]: value}
+	for i := len(path) - 2; i >= 0; i-- {
+		m = map[string]interface{}{path[i]: m}
+	}
+	return m
+}
+
+// WriteConfig writes the configuration to the config file, leaving out the
+// values that come from environment variables or --set flags.
+func WriteConfig() error {
+	invalidate()
+	return writeSettings(persistentSettings())
+}
+
+// Unset removes keys from the config file, writing the rest of the
+// configuration as WriteConfig does.
+func Unset(keys ...string) {
+	settings := persistentSettings()
+	for _, key := range keys {
+		remove(settings, key)
+	}
+	check(writeSettings(settings))
+
+	check(viper.ReadInConfig())
+	for key, o := range overrides {
+		check(viper.MergeConfigMap(nestedMap(key, o.value)))
+	}
+	invalidate()
+}
+
+// persistentSettings returns the current settings without the overrides.
+func persistentSettings() map[string]interface{} {
+	settings := viper.AllSettings()
+	settings["version"] = CurrentVersion
+	for key, o := range overrides {
+		current, ok := lookup(settings, key)
+		if !ok || fmt.Sprint(current) != fmt.Sprint(o.value) {
+			// changed after applying the override, keep the new value
+			continue
+		}
+		if original, ok := lookup(fileSettings, key); ok {
+			store(settings, key, original)
+		} else {
+			remove(settings, key)
+		}
+	}
+	return settings
+}
+
+func writeSettings(settings map[string]interface{}) error {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if err := v.MergeConfigMap(settings); err != nil {
+		return err
+	}
+	return v.WriteConfig()
+}
+
+func lookup(m map[string]interface{}, key string) (interface{}, bool) {
+	path := strings.Split(key, ".")
+	for _, k := range path[:len(path)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		m = next
+	}
+	v, ok := m[path[len(path)-1]]
+	return v, ok
+}
+
+func store(m map[string]interface{}, key string, value interface{}) {
+	path := strings.Split(key, ".")
+	for _, k := range path[:len(path)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			m[k] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+func remove(m map[string]interface{}, key string) {
+	path := strings.Split(key, ".")
+	for _, k := range path[:len(path)-1] {
+		next, ok := m[k].(map[string]interface{})
+		if !ok {
+			return
+		}
+		m = next
+	}
+	delete(m, path[len(path)-1])
+}
+
+// secretKeys are not printed by `config show`.
+var secretKeys = []string{"seed", "keystore.ciphertext"}
+
+func isSecret(key string) bool {
+	for _, s := range secretKeys {
+		if key == s || strings.HasSuffix(key, "."+s) {
+			return true
+		}
+	}
+	return false
+}
+
+func showCmd(args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s config show [--effective]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	settings := fileSettings
+	if showEffective {
+		settings = viper.AllSettings()
+	}
+	keys := make([]string, 0)
+	flatten("", settings, &keys)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, _ := lookup(settings, key)
+		s := fmt.Sprint(value)
+		if isSecret(key) && s != "" {
+			s = "********"
+		}
+		if !showEffective {
+			fmt.Printf("%s = %s\n", key, s)
+			continue
+		}
+		source := sourceFile
+		if o, ok := overrides[key]; ok {
+			source = o.source
+		}
+		fmt.Printf("%s = %s  (%s)\n", key, s, source)
+	}
+}
+
+func flatten(prefix string, m map[string]interface{}, keys *[]string) {
+	for k, v := range m {
+		if sub, ok := v.(map[string]interface{}); ok {
+			flatten(prefix+k+".", sub, keys)
+			continue
+		}
+		*keys = append(*keys, prefix+k)
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import "testing"
+
+func TestEnvKey(t *testing.T) {
+	for name, want := range map[string]string{
+		"WWALLET_GOSHIMMER_API":     "goshimmer.api",
+		"WWALLET_SC_FR_QUORUM":      "sc.fr.quorum",
+		"WWALLET_CONTACTS_MY__SHOP": "contacts.my_shop",
+		"WWALLET_NODES_NODE__A_API": "nodes.node_a.api",
+		"WWALLET_POLICY_ALLOWYES":   "policy.allowyes",
+	} {
+		if got := envKey(name); got != want {
+			t.Errorf("envKey(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestProfileKey(t *testing.T) {
+	profileFlag = "demo"
+	defer func() { profileFlag = "" }()
+
+	for key, want := range map[string]string{
+		"goshimmer.api":         "profiles.demo.goshimmer.api",
+		"sc.fr.address":         "profiles.demo.sc.fr.address",
+		"utxodb":                "profiles.demo.utxodb",
+		"contacts.shop":         "contacts.shop",
+		"profiles.x.wasp.0.api": "profiles.x.wasp.0.api",
+		"wallet.signer.socket":  "wallet.signer.socket",
+	} {
+		if got := profileKey(key); got != want {
+			t.Errorf("profileKey(%s) = %s, want %s", key, got, want)
+		}
+	}
+}
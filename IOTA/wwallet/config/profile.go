@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	profileEnvVar     = "WWALLET_PROFILE"
+	defaultProfile    = "default"
+	profileConfigVar  = "profile"
+	profilesConfigVar = "profiles"
+)
+
+// networkKeys are the top-level config keys that describe a network, and are
+// therefore scoped to the selected profile.
+var networkKeys = []string{"goshimmer", "wasp", "nodes", "sc", "utxodb"}
+
+var profileFlag string
+
+// Profile returns the network profile selected with --profile, with the
+// WWALLET_PROFILE environment variable, or with `profile use`.
+func Profile() string {
+	name := profileFlag
+	if name == "" {
+		name = os.Getenv(profileEnvVar)
+	}
+	if name == "" {
+		name = Current().Profile
+	}
+	if name == "" {
+		return defaultProfile
+	}
+	return strings.ToLower(name)
+}
+
+// checkProfile fails if the selected profile does not exist, so that a typo
+// in --profile or WWALLET_PROFILE does not silently use empty settings.
+func checkProfile() {
+	name := Profile()
+	if profileExists(name) {
+		return
+	}
+	source := "the " + profileConfigVar + " config key"
+	if profileFlag != "" {
+		source = "--profile"
+	} else if os.Getenv(profileEnvVar) != "" {
+		source = profileEnvVar
+	}
+	check(fmt.Errorf("no profile named %s (selected with %s); available: %s",
+		name, source, strings.Join(Profiles(), ", ")))
+}
+
+func profilePrefix(name string) string {
+	if name == defaultProfile {
+		return ""
+	}
+	return profilesConfigVar + "." + name + "."
+}
+
+// ProfileConfigVar returns the config key of a network setting in the
+// selected profile. The default profile uses the top-level keys.
+func ProfileConfigVar(key string) string {
+	return profilePrefix(Profile()) + key
+}
+
+func Profiles() []string {
+	names := make([]string, 0)
+	for name := range Current().Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{defaultProfile}, names...)
+}
+
+func profileExists(name string) bool {
+	for _, n := range Profiles() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UseUtxodb tells whether to use the utxodb client, either because of -u or
+// because the selected profile enables it.
+func UseUtxodb() bool {
+	return Utxodb || Current().SelectedNetwork().Utxodb
+}
+
+var profileSubcmds = map[string]func([]string){
+	"list": profileListCmd,
+	"copy": profileCopyCmd,
+	"use":  profileUseCmd,
+}
+
+func profileCmd(args []string) {
+	if len(args) < 1 {
+		profileUsage()
+	}
+	subcmd, ok := profileSubcmds[args[0]]
+	if !ok {
+		profileUsage()
+	}
+	subcmd(args[1:])
+}
+
+func profileUsage() {
+	cmdNames := make([]string, 0)
+	for k := range profileSubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s profile [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func profileListCmd(args []string) {
+	current := Profile()
+	for _, name := range Profiles() {
+		marker := " "
+		if name == current {
+			marker = "*"
+		}
+		network := Current().ProfileNetwork(name)
+		goshimmer := network.Goshimmer.Api
+		if goshimmer == "" {
+			goshimmer = "127.0.0.1:8080"
+		}
+		fmt.Printf("%s %s: goshimmer %s, %d SC aliases\n", marker, name, goshimmer, len(network.SC))
+	}
+}
+
+func profileCopyCmd(args []string) {
+	if len(args) != 2 {
+		fmt.Printf("Usage: %s profile copy <from> <to>\n", os.Args[0])
+		os.Exit(1)
+	}
+	from, to := strings.ToLower(args[0]), strings.ToLower(args[1])
+	if !profileExists(from) {
+		check(fmt.Errorf("no profile named %s", from))
+	}
+	if strings.Contains(to, ".") {
+		check(fmt.Errorf("profile name cannot contain '.'"))
+	}
+	if profileExists(to) {
+		check(fmt.Errorf("profile %s already exists", to))
+	}
+	for _, key := range networkKeys {
+		if v := viper.Get(profilePrefix(from) + key); v != nil {
+			viper.Set(profilePrefix(to)+key, v)
+		}
+	}
+	// make sure the profile exists even if the source is empty
+	if viper.Get(profilePrefix(to)+"utxodb") == nil {
+		viper.Set(profilePrefix(to)+"utxodb", false)
+	}
+	check(WriteConfig())
+	fmt.Printf("Profile %s copied to %s\n", from, to)
+}
+
+func profileUseCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s profile use <name>\n", os.Args[0])
+		os.Exit(1)
+	}
+	name := strings.ToLower(args[0])
+	if !profileExists(name) {
+		check(fmt.Errorf("no profile named %s; create it with `profile copy`", name))
+	}
+	viper.Set(profileConfigVar, name)
+	check(WriteConfig())
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"time"
+
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// reservationTTL is how long the inputs of a built transaction stay reserved
+// if it is never confirmed.
+const reservationTTL = 10 * time.Minute
+
+// LockWallet serializes the wallet state across concurrent wwallet
+// invocations. The returned function releases the lock.
+//
+// The lock is not reentrant and the exit hooks take it again, so nothing may
+// exit through check while holding it: functions called under the lock
+// return their errors instead.
+func LockWallet() func() {
+	unlock, err := journal.Lock(configPathWithExt(".lock"))
+	check(err)
+	return unlock
+}
+
+// UnreservedOutputs filters out the outputs that are inputs of transactions
+// built by another invocation. The caller must hold the wallet lock.
+func UnreservedOutputs(outs map[transaction.OutputID][]*balance.Balance) (map[transaction.OutputID][]*balance.Balance, error) {
+	r, err := journal.ReadReservations(reservationsPath())
+	if err != nil {
+		return nil, err
+	}
+	return r.Unreserved(outs), nil
+}
+
+// cancelRelease holds, for each transaction reserved by this process and not
+// posted yet, the function that cancels its release at exit.
+var cancelRelease = map[transaction.ID]func(){}
+
+// ReserveInputs marks the inputs of the transaction as in flight. The caller
+// must hold the wallet lock. Unless the transaction is posted or written with
+// --unsigned-out (see KeepReservation), the reservation is undone when the
+// command fails.
+func ReserveInputs(tx *transaction.Transaction) error {
+	r, err := journal.ReadReservations(reservationsPath())
+	if err != nil {
+		return err
+	}
+	r.Reserve(tx, reservationTTL)
+	if err := r.Write(reservationsPath()); err != nil {
+		return err
+	}
+
+	cancelRelease[tx.ID()] = AtExit(func() { releaseInputs(tx) })
+	return nil
+}
+
+// KeepReservation keeps the inputs of a posted or written out transaction
+// reserved until it is confirmed or the reservation expires.
+func KeepReservation(tx *transaction.Transaction) {
+	if cancel, ok := cancelRelease[tx.ID()]; ok {
+		cancel()
+		delete(cancelRelease, tx.ID())
+	}
+}
+
+// ReleaseInputs makes the inputs of a transaction that could not be posted
+// available again.
+func ReleaseInputs(tx *transaction.Transaction) {
+	KeepReservation(tx)
+	releaseInputs(tx)
+}
+
+func releaseInputs(tx *transaction.Transaction) {
+	unlock, err := journal.Lock(configPathWithExt(".lock"))
+	if err != nil {
+		warn(err)
+		return
+	}
+	defer unlock()
+
+	r, err := journal.ReadReservations(reservationsPath())
+	if err == nil {
+		r.Release(tx)
+		err = r.Write(reservationsPath())
+	}
+	if err != nil {
+		warn(err)
+	}
+}
+
+func reservationsPath() string {
+	return configPathWithExt(".reserved")
+}
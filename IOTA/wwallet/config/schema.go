@@ -0,0 +1,245 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// File is the layout of wwallet.json. The settings of the network (hosts,
+// smart contracts) are at the top level for the default profile and under
+// profiles.<name> for the others.
+type File struct {
+	Version    int
+	Profile    string
+	Identity   string
+	Network    `mapstructure:",squash"`
+	Profiles   map[string]*Network
+	Wallet     *Wallet
+	Identities map[string]*Identity
+	Contacts   map[string]string
+	Policy     *PolicySection
+}
+
+type Network struct {
+	Goshimmer *GoshimmerSection
+	Wasp      *WaspSection
+	Nodes     map[string]*Node
+	SC        map[string]*SCSection `mapstructure:"sc"`
+	Utxodb    bool
+}
+
+type GoshimmerSection struct {
+	Api string
+}
+
+// WaspSection holds the wasp endpoints used outside of committees.
+type WaspSection struct {
+	Api     string
+	Nanomsg string
+}
+
+// Node is a wasp node registered by name, see `node add`.
+type Node struct {
+	Api     string
+	Peering string
+	Nanomsg string
+	PubKey  string
+}
+
+type SCSection struct {
+	Address   string
+	Committee []string
+	Quorum    int
+}
+
+type Wallet struct {
+	Seed     string
+	Keystore *Keystore
+	Watch    *Watch
+	Signer   *Signer
+}
+
+// Identity is a Wallet with its own seed, or an index on the default seed.
+type Identity struct {
+	Wallet `mapstructure:",squash"`
+	Index  string
+}
+
+type Keystore struct {
+	Kdf        string
+	N          int
+	R          int
+	P          int
+	Salt       string
+	Nonce      string
+	Ciphertext string
+}
+
+type Watch struct {
+	Address   string
+	PublicKey string
+}
+
+type Signer struct {
+	Socket string
+}
+
+type PolicySection struct {
+	Allowlist []string
+	AllowYes  bool
+	Limits    []PolicyLimit
+}
+
+type PolicyLimit struct {
+	Color        string
+	MaxPerTx     int64
+	MaxPerDay    int64
+	ConfirmAbove int64
+}
+
+// DecodeFile decodes the settings into a File, and returns the keys that are
+// not part of the layout.
+func DecodeFile(settings map[string]interface{}) (*File, []string, error) {
+	f := &File{}
+	md := &mapstructure.Metadata{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Metadata:         md,
+		Result:           f,
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := decoder.Decode(settings); err != nil {
+		return nil, nil, err
+	}
+	return f, md.Unused, nil
+}
+
+// DefaultIdentity is the identity stored in the wallet section.
+const DefaultIdentity = "default"
+
+var current *File
+
+// Current returns the configuration, including the overrides, decoded into
+// a File. It is decoded once, and again after the configuration is changed
+// with Set, Unset or WriteConfig.
+func Current() *File {
+	if current == nil {
+		f, _, err := DecodeFile(viper.AllSettings())
+		if err != nil {
+			check(fmt.Errorf("%s: %v", configPath, err))
+		}
+		f.normalize()
+		current = f
+	}
+	return current
+}
+
+func invalidate() {
+	current = nil
+}
+
+// normalize replaces the missing sections with empty ones, so that the
+// accessors do not need to check for nil.
+func (f *File) normalize() {
+	f.Network.normalize()
+	for name, n := range f.Profiles {
+		if n == nil {
+			n = &Network{}
+			f.Profiles[name] = n
+		}
+		n.normalize()
+	}
+	if f.Wallet == nil {
+		f.Wallet = &Wallet{}
+	}
+	f.Wallet.normalize()
+	for name, id := range f.Identities {
+		if id == nil {
+			id = &Identity{}
+			f.Identities[name] = id
+		}
+		id.Wallet.normalize()
+	}
+	if f.Contacts == nil {
+		f.Contacts = make(map[string]string)
+	}
+	if f.Policy == nil {
+		f.Policy = &PolicySection{}
+	}
+}
+
+func (n *Network) normalize() {
+	if n.Goshimmer == nil {
+		n.Goshimmer = &GoshimmerSection{}
+	}
+	if n.Wasp == nil {
+		n.Wasp = &WaspSection{}
+	}
+	for name, node := range n.Nodes {
+		if node == nil {
+			n.Nodes[name] = &Node{}
+		}
+	}
+	for alias, sc := range n.SC {
+		if sc == nil {
+			n.SC[alias] = &SCSection{}
+		}
+	}
+}
+
+func (w *Wallet) normalize() {
+	if w.Keystore == nil {
+		w.Keystore = &Keystore{}
+	}
+	if w.Watch == nil {
+		w.Watch = &Watch{}
+	}
+	if w.Signer == nil {
+		w.Signer = &Signer{}
+	}
+}
+
+// SelectedNetwork returns the network settings of the selected profile.
+func (f *File) SelectedNetwork() *Network {
+	return f.ProfileNetwork(Profile())
+}
+
+func (f *File) ProfileNetwork(name string) *Network {
+	if name == defaultProfile {
+		return &f.Network
+	}
+	if n, ok := f.Profiles[name]; ok {
+		return n
+	}
+	n := &Network{}
+	n.normalize()
+	return n
+}
+
+// SCSection returns the settings of the SC with the given alias, empty if
+// there are none.
+func (n *Network) SCSection(alias string) *SCSection {
+	if sc, ok := n.SC[alias]; ok {
+		return sc
+	}
+	return &SCSection{}
+}
+
+// IdentitySection returns the settings of the named identity, where the default
+// identity is the wallet section.
+func (f *File) IdentitySection(name string) *Identity {
+	if name == DefaultIdentity {
+		return &Identity{Wallet: *f.Wallet}
+	}
+	if id, ok := f.Identities[name]; ok {
+		return id
+	}
+	id := &Identity{}
+	id.Wallet.normalize()
+	return id
+}
@@ -34,7 +34,7 @@ func MakeTemplate(parts ...string) *template.Template {
 			dashboard.ExploreAddressUrlFromGoshimmerUri(config.GoshimmerApi()),
 		),
 		"waspClientCmd": func() string {
-			if config.Utxodb {
+			if config.UseUtxodb() {
 				return os.Args[0] + " -u"
 			}
 			return os.Args[0]
@@ -8,5 +8,6 @@ import (
 func handleWwalletJson(c echo.Context) error {
 	settings := viper.AllSettings()
 	delete(settings, "wallet")
+	delete(settings, "identities")
 	return c.JSONPretty(200, settings, " ")
 }
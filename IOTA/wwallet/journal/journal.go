@@ -0,0 +1,162 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+
+	"wasp/packages/sctransaction"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+const (
+	StatePending   = "pending"
+	StateConfirmed = "confirmed"
+	StateRejected  = "rejected"
+
+	KindValue     = "value"
+	KindMint      = "mint"
+	KindSCRequest = "sc-request"
+	KindBurn      = "burn"
+)
+
+// Entry is a line of the journal. The first entry for a transaction
+// describes it; later entries with the same TxID only update its State, or
+// record the tokens it burned.
+type Entry struct {
+	TxID    string                      `json:"txid"`
+	Time    time.Time                   `json:"time"`
+	Kind    string                      `json:"kind,omitempty"`
+	Inputs  []string                    `json:"inputs,omitempty"`
+	Outputs map[string]map[string]int64 `json:"outputs,omitempty"`
+	Burned  map[string]int64            `json:"burned,omitempty"`
+	State   string                      `json:"state,omitempty"`
+}
+
+func NewEntry(tx *transaction.Transaction) *Entry {
+	e := &Entry{
+		TxID:    tx.ID().String(),
+		Time:    time.Now().UTC(),
+		Kind:    KindValue,
+		Outputs: make(map[string]map[string]int64),
+		State:   StatePending,
+	}
+	inputs := make(map[address.Address]bool)
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		if !inputs[outputID.Address()] {
+			inputs[outputID.Address()] = true
+			e.Inputs = append(e.Inputs, outputID.Address().String())
+		}
+		return true
+	})
+	tx.Outputs().ForEach(func(addr address.Address, bals []*balance.Balance) bool {
+		byColor := make(map[string]int64)
+		for _, bal := range bals {
+			if bal.Color == balance.ColorNew {
+				e.Kind = KindMint
+			}
+			byColor[bal.Color.String()] += bal.Value
+		}
+		e.Outputs[addr.String()] = byColor
+		return true
+	})
+	if sctx, err := sctransaction.ParseValueTransaction(tx); err == nil && len(sctx.Requests()) > 0 {
+		e.Kind = KindSCRequest
+	}
+	return e
+}
+
+func StateUpdate(txid transaction.ID, state string) *Entry {
+	return &Entry{
+		TxID:  txid.String(),
+		Time:  time.Now().UTC(),
+		State: state,
+	}
+}
+
+// BurnUpdate records that a transaction recolored amount tokens of color to
+// IOTA.
+func BurnUpdate(txid transaction.ID, color balance.Color, amount int64) *Entry {
+	return &Entry{
+		TxID:   txid.String(),
+		Time:   time.Now().UTC(),
+		Kind:   KindBurn,
+		Burned: map[string]int64{color.String(): amount},
+	}
+}
+
+// Burned returns the amount of color burned by the transactions that were
+// not rejected.
+func Burned(entries []*Entry, color balance.Color) int64 {
+	var ret int64
+	for _, e := range entries {
+		if e.State != StateRejected {
+			ret += e.Burned[color.String()]
+		}
+	}
+	return ret
+}
+
+func Append(path string, e *Entry) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Read returns one entry per transaction, in the order they were first
+// recorded, with the latest known state.
+func Read(path string) ([]*Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ret := make([]*Entry, 0)
+	byID := make(map[string]*Entry)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		e := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			return nil, err
+		}
+		if prev, ok := byID[e.TxID]; ok {
+			prev.update(e)
+			continue
+		}
+		byID[e.TxID] = e
+		ret = append(ret, e)
+	}
+	return ret, scanner.Err()
+}
+
+func (e *Entry) update(u *Entry) {
+	if u.State != "" {
+		e.State = u.State
+	}
+	if len(u.Burned) > 0 {
+		e.Kind = u.Kind
+		if e.Burned == nil {
+			e.Burned = make(map[string]int64)
+		}
+		for color, amount := range u.Burned {
+			e.Burned[color] += amount
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func TestReadMergesUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wwallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "wwallet.journal")
+
+	color := balance.Color{1, 2, 3}
+	burn, rejected := transaction.ID{1}, transaction.ID{2}
+	for _, e := range []*Entry{
+		{TxID: burn.String(), Time: time.Now(), Kind: KindValue, State: StatePending},
+		BurnUpdate(burn, color, 10),
+		StateUpdate(burn, StateConfirmed),
+		{TxID: rejected.String(), Time: time.Now(), Kind: KindValue, State: StatePending},
+		BurnUpdate(rejected, color, 1000),
+		StateUpdate(rejected, StateRejected),
+	} {
+		if err := Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("%d entries, want one per transaction", len(entries))
+	}
+	if e := entries[0]; e.State != StateConfirmed || e.Kind != KindBurn || e.Burned[color.String()] != 10 {
+		t.Fatalf("burn entry = %+v", e)
+	}
+	if got := Burned(entries, color); got != 10 {
+		t.Fatalf("burned = %d, want 10 without the rejected transaction", got)
+	}
+	if got := Burned(entries, balance.ColorIOTA); got != 0 {
+		t.Fatalf("burned IOTA = %d", got)
+	}
+}
@@ -0,0 +1,24 @@
+package journal
+
+import (
+	"os"
+	"syscall"
+)
+
+// Lock takes an exclusive advisory lock on the given file, blocking until it
+// is available, and returns the function that releases it. The lock is not
+// reentrant: it must not be taken twice by the same process.
+func Lock(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,77 @@
+package journal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// Pending is a posted transaction that has not been seen confirmed yet. The
+// serialized transaction is kept so that it can be rebroadcast.
+type Pending struct {
+	TxID     string    `json:"txid"`
+	Bytes    []byte    `json:"bytes"`
+	Posted   time.Time `json:"posted"`
+	Attempts int       `json:"attempts"`
+}
+
+func (p *Pending) Transaction() (*transaction.Transaction, error) {
+	tx, _, err := transaction.FromBytes(p.Bytes)
+	return tx, err
+}
+
+func ReadPending(path string) (map[string]*Pending, error) {
+	ret := make(map[string]*Pending)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func writePending(path string, pending map[string]*Pending) error {
+	b, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// AddPending records a (re)broadcast of the transaction. It returns true if
+// the transaction was not pending before.
+func AddPending(path string, tx *transaction.Transaction) (bool, error) {
+	pending, err := ReadPending(path)
+	if err != nil {
+		return false, err
+	}
+	txid := tx.ID().String()
+	p, ok := pending[txid]
+	if !ok {
+		p = &Pending{TxID: txid, Bytes: tx.Bytes()}
+		pending[txid] = p
+	}
+	p.Posted = time.Now().UTC()
+	p.Attempts++
+	return !ok, writePending(path, pending)
+}
+
+func RemovePending(path string, txid transaction.ID) error {
+	pending, err := ReadPending(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := pending[txid.String()]; !ok {
+		return nil
+	}
+	delete(pending, txid.String())
+	return writePending(path, pending)
+}
@@ -0,0 +1,70 @@
+package journal
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// Reservations maps the outputs used as inputs by in-flight transactions to
+// the time the reservation expires. Callers must hold the wallet lock.
+type Reservations map[string]time.Time
+
+func ReadReservations(path string) (Reservations, error) {
+	ret := make(Reservations)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for id, expires := range ret {
+		if now.After(expires) {
+			delete(ret, id)
+		}
+	}
+	return ret, nil
+}
+
+func (r Reservations) Write(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// Unreserved returns the outputs that are not reserved.
+func (r Reservations) Unreserved(outs map[transaction.OutputID][]*balance.Balance) map[transaction.OutputID][]*balance.Balance {
+	ret := make(map[transaction.OutputID][]*balance.Balance)
+	for id, bals := range outs {
+		if _, ok := r[id.String()]; !ok {
+			ret[id] = bals
+		}
+	}
+	return ret
+}
+
+func (r Reservations) Reserve(tx *transaction.Transaction, ttl time.Duration) {
+	expires := time.Now().Add(ttl)
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		r[outputID.String()] = expires
+		return true
+	})
+}
+
+func (r Reservations) Release(tx *transaction.Transaction) {
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		delete(r, outputID.String())
+		return true
+	})
+}
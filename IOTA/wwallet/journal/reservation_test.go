@@ -0,0 +1,120 @@
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func testOutputID(i byte) transaction.OutputID {
+	return transaction.NewOutputID(address.Address{1}, transaction.ID{i})
+}
+
+func testOutputs(ids ...transaction.OutputID) map[transaction.OutputID][]*balance.Balance {
+	ret := make(map[transaction.OutputID][]*balance.Balance)
+	for _, id := range ids {
+		ret[id] = []*balance.Balance{balance.New(balance.ColorIOTA, 1)}
+	}
+	return ret
+}
+
+func testTransaction(inputs ...transaction.OutputID) *transaction.Transaction {
+	return transaction.New(
+		transaction.NewInputs(inputs...),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			{2}: {balance.New(balance.ColorIOTA, int64(len(inputs)))},
+		}),
+	)
+}
+
+func TestUnreserved(t *testing.T) {
+	a, b, c := testOutputID(1), testOutputID(2), testOutputID(3)
+	outs := testOutputs(a, b, c)
+
+	for name, tc := range map[string]struct {
+		reserved []transaction.OutputID
+		want     []transaction.OutputID
+	}{
+		"none reserved":  {nil, []transaction.OutputID{a, b, c}},
+		"some reserved":  {[]transaction.OutputID{b}, []transaction.OutputID{a, c}},
+		"all reserved":   {[]transaction.OutputID{a, b, c}, nil},
+		"other reserved": {[]transaction.OutputID{testOutputID(9)}, []transaction.OutputID{a, b, c}},
+	} {
+		r := make(Reservations)
+		if len(tc.reserved) > 0 {
+			r.Reserve(testTransaction(tc.reserved...), time.Minute)
+		}
+		got := r.Unreserved(outs)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: %d unreserved outputs, want %d", name, len(got), len(tc.want))
+			continue
+		}
+		for _, id := range tc.want {
+			if _, ok := got[id]; !ok {
+				t.Errorf("%s: output %s is reserved", name, id)
+			}
+		}
+	}
+}
+
+func TestReleaseMakesOutputsAvailable(t *testing.T) {
+	a, b := testOutputID(1), testOutputID(2)
+	r := make(Reservations)
+	tx := testTransaction(a)
+	r.Reserve(tx, time.Minute)
+	r.Reserve(testTransaction(b), time.Minute)
+	r.Release(tx)
+
+	got := r.Unreserved(testOutputs(a, b))
+	if _, ok := got[a]; !ok {
+		t.Errorf("released output %s is still reserved", a)
+	}
+	if _, ok := got[b]; ok {
+		t.Errorf("output %s of another transaction was released", b)
+	}
+}
+
+func TestReadReservationsDropsExpired(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wwallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "wwallet.reserved")
+
+	a, b := testOutputID(1), testOutputID(2)
+	r := make(Reservations)
+	r.Reserve(testTransaction(a), -time.Minute)
+	r.Reserve(testTransaction(b), time.Minute)
+	if err := r.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = ReadReservations(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := r.Unreserved(testOutputs(a, b))
+	if _, ok := got[a]; !ok {
+		t.Errorf("expired reservation of %s was kept", a)
+	}
+	if _, ok := got[b]; ok {
+		t.Errorf("reservation of %s was dropped before expiring", b)
+	}
+}
+
+func TestReadReservationsMissingFile(t *testing.T) {
+	r, err := ReadReservations(filepath.Join(os.TempDir(), "wwallet-no-such-file.reserved"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 0 {
+		t.Fatalf("reservations from a missing file: %v", r)
+	}
+}
@@ -8,12 +8,15 @@ import (
 	config "wasp/tools/wwallet/config"
 
 	"wasp/tools/wwallet/dashboard/dashboardcmd"
+	"wasp/tools/wwallet/policy"
 	"wasp/tools/wwallet/program"
 	"wasp/tools/wwallet/sc/dwf/dwfcmd"
 	"wasp/tools/wwallet/sc/fa/facmd"
 	"wasp/tools/wwallet/sc/fr/frcmd"
 	"wasp/tools/wwallet/sc/sccmd"
+	"wasp/tools/wwallet/sc/tr"
 	"wasp/tools/wwallet/sc/tr/trcmd"
+	"wasp/tools/wwallet/txcmd"
 	"wasp/tools/wwallet/wallet"
 
 	"github.com/spf13/pflag"
@@ -22,7 +25,7 @@ import (
 func check(err error) {
 	if err != nil {
 		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
+		config.Exit(1)
 	}
 }
 
@@ -42,7 +45,7 @@ func main() {
 	flags := pflag.NewFlagSet("global flags", pflag.ExitOnError)
 
 	config.InitCommands(commands, flags)
-	wallet.InitCommands(commands, flags)
+	wallet.InitCommands(commands, flags, tr.Registry)
 	frcmd.InitCommands(commands)
 	facmd.InitCommands(commands)
 	trcmd.InitCommands(commands)
@@ -51,6 +54,8 @@ func main() {
 	dashboardcmd.InitCommands(commands, flags)
 	sccmd.InitCommands(commands, flags)
 	program.InitCommands(commands, flags)
+	txcmd.InitCommands(commands, flags)
+	policy.InitCommands(commands, flags, wallet.PolicyOwner)
 	check(flags.Parse(os.Args[1:]))
 
 	config.Read()
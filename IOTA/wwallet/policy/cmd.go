@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func policyCmd(owner func() Owner, args []string) {
+	if len(args) != 1 || (args[0] != "show" && args[0] != "blocked") {
+		fmt.Printf("Usage: %s policy [show|blocked]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	if args[0] == "blocked" {
+		b, err := ioutil.ReadFile(LogPath())
+		if os.IsNotExist(err) {
+			fmt.Printf("No blocked attempts\n")
+			return
+		}
+		check(err)
+		fmt.Print(string(b))
+		return
+	}
+
+	p := Load()
+	fmt.Printf("Allowlist:\n")
+	for _, s := range p.Allowlist {
+		fmt.Printf("  %s\n", s)
+	}
+	fmt.Printf("--yes allowed: %v\n", p.AllowYes)
+	spent := spentToday(owner())
+	fmt.Printf("Limits:\n")
+	for i := range p.Limits {
+		l := &p.Limits[i]
+		fmt.Printf("  %s:\n", l.Color)
+		fmt.Printf("    Max per transaction: %d\n", l.MaxPerTx)
+		fmt.Printf("    Max per day: %d\n", l.MaxPerDay)
+		fmt.Printf("    Confirm above: %d\n", l.ConfirmAbove)
+		fmt.Printf("    Spent today: %d\n", spent[p.color(l)])
+	}
+}
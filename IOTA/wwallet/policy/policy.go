@@ -0,0 +1,337 @@
+package policy
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"wasp/packages/util"
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Policy is read from the `policy` section of wwallet.json:
+//
+//	"policy": {
+//	  "allowlist": ["alice", "dwf"],
+//	  "allowyes": false,
+//	  "limits": [{"color": "IOTA", "maxpertx": 1000, "maxperday": 5000, "confirmabove": 100}]
+//	}
+type Policy config.PolicySection
+
+type Limit = config.PolicyLimit
+
+var yes bool
+
+// Owner tells whether a base58 address belongs to the identity that signs:
+// only the transactions it posted count towards its daily limits.
+type Owner func(b58 string) bool
+
+// InitCommands registers the policy command. owner returns the Owner of the
+// current identity.
+func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet, owner func() Owner) {
+	commands["policy"] = func(args []string) { policyCmd(owner, args) }
+
+	fs := pflag.NewFlagSet("policy", pflag.ExitOnError)
+	fs.BoolVarP(&yes, "yes", "y", false, "skip the spending confirmation, if the policy allows it")
+	flags.AddFlagSet(fs)
+}
+
+func Load() *Policy {
+	return (*Policy)(config.Current().Policy)
+}
+
+func (p *Policy) limit(color balance.Color) *Limit {
+	for i := range p.Limits {
+		if p.color(&p.Limits[i]) == color {
+			return &p.Limits[i]
+		}
+	}
+	return nil
+}
+
+func (p *Policy) color(l *Limit) balance.Color {
+	c, err := util.ColorFromString(l.Color)
+	check(err)
+	return c
+}
+
+// Wrap returns a signature scheme that enforces the spending policy before
+// signing, so that every signing path of the wallet goes through it.
+func Wrap(sigScheme signaturescheme.SignatureScheme, owner Owner) signaturescheme.SignatureScheme {
+	return &policySignatureScheme{sigScheme, owner}
+}
+
+type policySignatureScheme struct {
+	signaturescheme.SignatureScheme
+	owner Owner
+}
+
+// approved holds the essences already checked by this process, since a
+// transaction with inputs from several addresses is signed several times.
+var approved = make(map[[32]byte]bool)
+
+func (s *policySignatureScheme) Sign(data []byte) signaturescheme.Signature {
+	h := blake2b.Sum256(data)
+	if !approved[h] {
+		check(Check(data, s.owner))
+		approved[h] = true
+	}
+	return s.SignatureScheme.Sign(data)
+}
+
+// Preview returns a signature scheme for --dry-run: instead of signing it
+// reports the verdict of the policy, and returns an empty signature, so that
+// neither the seed nor an external signer is asked for a signature.
+func Preview(addr address.Address, owner Owner) signaturescheme.SignatureScheme {
+	return &previewSignatureScheme{addr, owner}
+}
+
+type previewSignatureScheme struct {
+	address address.Address
+	owner   Owner
+}
+
+func (s *previewSignatureScheme) Version() byte {
+	return address.VersionED25519
+}
+
+func (s *previewSignatureScheme) Address() address.Address {
+	return s.address
+}
+
+func (s *previewSignatureScheme) Sign(data []byte) signaturescheme.Signature {
+	h := blake2b.Sum256(data)
+	if !approved[h] {
+		Report(data, s.owner)
+		approved[h] = true
+	}
+	b := make([]byte, 1+ed25519.PublicKeySize+ed25519.SignatureSize)
+	b[0] = address.VersionED25519
+	sig, _, err := signaturescheme.Ed25519SignatureFromBytes(b)
+	check(err)
+	return sig
+}
+
+// violation is a reason for the policy to block a transaction.
+type violation string
+
+func (v violation) Error() string {
+	return string(v)
+}
+
+// Check enforces the policy on a transaction essence about to be signed.
+func Check(essence []byte, owner Owner) error {
+	p := Load()
+	confirm, destinations, err := p.evaluate(essence, spentToday(owner))
+	if v, ok := err.(violation); ok {
+		return block("%s", v)
+	}
+	if err != nil {
+		return err
+	}
+	if p.needsConfirmation(confirm) && !confirmInteractively(confirm, destinations) {
+		return block("confirmation of %s was refused", strings.Join(confirm, ", "))
+	}
+	return nil
+}
+
+// Report prints the verdict of the policy on a transaction essence, without
+// asking for confirmation or logging blocked attempts.
+func Report(essence []byte, owner Owner) {
+	p := Load()
+	confirm, _, err := p.evaluate(essence, spentToday(owner))
+	if _, ok := err.(violation); ok {
+		fmt.Printf("Spending policy: would be blocked: %s\n", err)
+		return
+	}
+	check(err)
+	if p.needsConfirmation(confirm) {
+		fmt.Printf("Spending policy: would ask to confirm %s\n", strings.Join(confirm, ", "))
+		return
+	}
+	fmt.Printf("Spending policy: allowed\n")
+}
+
+// needsConfirmation tells whether the amounts to confirm must be confirmed
+// interactively, i.e. they cannot be approved with --yes.
+func (p *Policy) needsConfirmation(confirm []string) bool {
+	return len(confirm) > 0 && !(yes && p.AllowYes)
+}
+
+// evaluate returns the amounts that need a confirmation and the destinations
+// of the transaction, or a violation if the policy blocks it. spentToday is
+// what the identity already spent today, by color.
+func (p *Policy) evaluate(essence []byte, spentToday map[balance.Color]int64) ([]string, []address.Address, error) {
+	spent, destinations, err := spending(essence)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(p.Allowlist) > 0 {
+		allowed := make(map[address.Address]bool)
+		for _, s := range p.Allowlist {
+			addr, err := config.ResolveAddress(s)
+			if err != nil {
+				return nil, nil, fmt.Errorf("policy allowlist: %v", err)
+			}
+			allowed[addr] = true
+		}
+		for _, addr := range destinations {
+			if !allowed[addr] {
+				return nil, nil, violation(fmt.Sprintf("destination %s is not in the allowlist", addr))
+			}
+		}
+	}
+
+	var confirm []string
+	for color, amount := range spent {
+		l := p.limit(color)
+		if l == nil {
+			continue
+		}
+		if l.MaxPerTx > 0 && amount > l.MaxPerTx {
+			return nil, nil, violation(fmt.Sprintf("%d %s exceeds the limit of %d per transaction", amount, color, l.MaxPerTx))
+		}
+		if l.MaxPerDay > 0 && spentToday[color]+amount > l.MaxPerDay {
+			return nil, nil, violation(fmt.Sprintf("%d %s exceeds the daily limit of %d (%d already spent today)", amount, color, l.MaxPerDay, spentToday[color]))
+		}
+		if l.ConfirmAbove > 0 && amount > l.ConfirmAbove {
+			confirm = append(confirm, fmt.Sprintf("%d %s", amount, color))
+		}
+	}
+	return confirm, destinations, nil
+}
+
+// spending returns the amounts by color sent to addresses that are not inputs
+// of the transaction, and those addresses. Newly minted tokens are counted as
+// IOTAs.
+func spending(essence []byte) (map[balance.Color]int64, []address.Address, error) {
+	inputs, n, err := valuetransaction.InputsFromBytes(essence)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputs, _, err := valuetransaction.OutputsFromBytes(essence[n:])
+	if err != nil {
+		return nil, nil, err
+	}
+	own := make(map[address.Address]bool)
+	inputs.ForEach(func(outputID valuetransaction.OutputID) bool {
+		own[outputID.Address()] = true
+		return true
+	})
+	spent := make(map[balance.Color]int64)
+	destinations := make([]address.Address, 0)
+	outputs.ForEach(func(addr address.Address, bals []*balance.Balance) bool {
+		if own[addr] {
+			return true
+		}
+		destinations = append(destinations, addr)
+		for _, bal := range bals {
+			color := bal.Color
+			if color == balance.ColorNew {
+				color = balance.ColorIOTA
+			}
+			spent[color] += bal.Value
+		}
+		return true
+	})
+	return spent, destinations, nil
+}
+
+// spentToday sums the spending of the journaled transactions posted by the
+// owner since local midnight.
+func spentToday(owner Owner) map[balance.Color]int64 {
+	entries, err := journal.Read(config.JournalPath())
+	check(err)
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return spentSince(entries, midnight, owner)
+}
+
+// spentSince sums the spending of the entries posted by the owner since the
+// given time, leaving out the rejected transactions.
+func spentSince(entries []*journal.Entry, since time.Time, owner Owner) map[balance.Color]int64 {
+	ret := make(map[balance.Color]int64)
+	for _, e := range entries {
+		if e.Time.Before(since) || e.State == journal.StateRejected {
+			continue
+		}
+		own := make(map[string]bool)
+		postedByOwner := false
+		for _, in := range e.Inputs {
+			own[in] = true
+			postedByOwner = postedByOwner || owner(in)
+		}
+		if !postedByOwner {
+			continue
+		}
+		for addr, byColor := range e.Outputs {
+			if own[addr] {
+				continue
+			}
+			for c, amount := range byColor {
+				color := balance.ColorIOTA
+				if c != balance.ColorNew.String() {
+					var err error
+					color, err = util.ColorFromString(c)
+					check(err)
+				}
+				ret[color] += amount
+			}
+		}
+	}
+	return ret
+}
+
+func confirmInteractively(amounts []string, destinations []address.Address) bool {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return false
+	}
+	fmt.Printf("About to send %s to:\n", strings.Join(amounts, ", "))
+	for _, addr := range destinations {
+		fmt.Printf("  %s\n", addr)
+	}
+	fmt.Printf("Confirm? [y/N] ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(strings.ToLower(answer)) == "y"
+}
+
+type blockedAttempt struct {
+	Time   time.Time `json:"time"`
+	Reason string    `json:"reason"`
+}
+
+func block(format string, args ...interface{}) error {
+	reason := fmt.Sprintf(format, args...)
+	b, _ := json.Marshal(&blockedAttempt{Time: time.Now().UTC(), Reason: reason})
+	f, err := os.OpenFile(LogPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err == nil {
+		_, _ = f.Write(append(b, '\n'))
+		f.Close()
+	}
+	return fmt.Errorf("blocked by spending policy: %s", reason)
+}
+
+func LogPath() string {
+	return config.JournalPath() + ".blocked"
+}
+
+func check(err error) {
+	if err != nil {
+		fmt.Printf("error: %s\n", err)
+		config.Exit(1)
+	}
+}
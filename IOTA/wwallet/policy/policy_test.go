@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var (
+	own   = address.Address{address.VersionED25519, 1}
+	alice = address.Address{address.VersionED25519, 2}
+	bob   = address.Address{address.VersionED25519, 3}
+)
+
+// testEssence sends amount IOTA from own to the target, with the change back
+// to own.
+func testEssence(target address.Address, amount int64, change int64) []byte {
+	outputs := map[address.Address][]*balance.Balance{
+		target: {balance.New(balance.ColorIOTA, amount)},
+	}
+	if change > 0 {
+		outputs[own] = []*balance.Balance{balance.New(balance.ColorIOTA, change)}
+	}
+	tx := valuetransaction.New(
+		valuetransaction.NewInputs(valuetransaction.NewOutputID(own, valuetransaction.ID{1})),
+		valuetransaction.NewOutputs(outputs),
+	)
+	return tx.EssenceBytes()
+}
+
+func iotaLimit(maxPerTx, maxPerDay, confirmAbove int64) []Limit {
+	return []Limit{{Color: balance.ColorIOTA.String(), MaxPerTx: maxPerTx, MaxPerDay: maxPerDay, ConfirmAbove: confirmAbove}}
+}
+
+func TestEvaluate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy     Policy
+		essence    []byte
+		spentToday int64
+		blocked    bool
+		confirm    []string
+	}{
+		"no policy":                 {Policy{}, testEssence(alice, 1000, 0), 0, false, nil},
+		"allowlisted destination":   {Policy{Allowlist: []string{alice.String()}}, testEssence(alice, 10, 0), 0, false, nil},
+		"destination not allowed":   {Policy{Allowlist: []string{alice.String()}}, testEssence(bob, 10, 0), 0, true, nil},
+		"change is not a recipient": {Policy{Allowlist: []string{alice.String()}}, testEssence(alice, 10, 90), 0, false, nil},
+		"within the per-tx limit":   {Policy{Limits: iotaLimit(100, 0, 0)}, testEssence(alice, 100, 0), 0, false, nil},
+		"above the per-tx limit":    {Policy{Limits: iotaLimit(100, 0, 0)}, testEssence(alice, 101, 0), 0, true, nil},
+		"change is not spent":       {Policy{Limits: iotaLimit(100, 0, 0)}, testEssence(alice, 50, 1000), 0, false, nil},
+		"within the daily limit":    {Policy{Limits: iotaLimit(0, 500, 0)}, testEssence(alice, 100, 0), 400, false, nil},
+		"above the daily limit":     {Policy{Limits: iotaLimit(0, 500, 0)}, testEssence(alice, 101, 0), 400, true, nil},
+		"below the confirmation":    {Policy{Limits: iotaLimit(0, 0, 100)}, testEssence(alice, 100, 0), 0, false, nil},
+		"above the confirmation":    {Policy{Limits: iotaLimit(0, 0, 100)}, testEssence(alice, 150, 0), 0, false, []string{"150 IOTA"}},
+		"blocked before confirming": {Policy{Limits: iotaLimit(100, 0, 50)}, testEssence(alice, 150, 0), 0, true, nil},
+	} {
+		spent := map[balance.Color]int64{balance.ColorIOTA: tc.spentToday}
+		confirm, _, err := tc.policy.evaluate(tc.essence, spent)
+		if _, ok := err.(violation); ok != tc.blocked {
+			t.Errorf("%s: blocked = %v (%v), want %v", name, ok, err, tc.blocked)
+			continue
+		}
+		if !tc.blocked && err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(confirm, tc.confirm) {
+			t.Errorf("%s: confirm = %v, want %v", name, confirm, tc.confirm)
+		}
+	}
+}
+
+func TestNeedsConfirmation(t *testing.T) {
+	defer func() { yes = false }()
+	for _, tc := range []struct {
+		confirm  []string
+		yes      bool
+		allowYes bool
+		want     bool
+	}{
+		{nil, false, false, false},
+		{[]string{"150 IOTA"}, false, false, true},
+		{[]string{"150 IOTA"}, true, false, true},
+		{[]string{"150 IOTA"}, false, true, true},
+		{[]string{"150 IOTA"}, true, true, false},
+	} {
+		yes = tc.yes
+		p := &Policy{AllowYes: tc.allowYes}
+		if got := p.needsConfirmation(tc.confirm); got != tc.want {
+			t.Errorf("confirm %v, --yes %v, allowyes %v: needsConfirmation = %v, want %v",
+				tc.confirm, tc.yes, tc.allowYes, got, tc.want)
+		}
+	}
+}
+
+func TestSpentSince(t *testing.T) {
+	since := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	entry := func(at time.Time, from address.Address, state string, outputs map[string]int64) *journal.Entry {
+		e := &journal.Entry{Time: at, Inputs: []string{from.String()}, State: state, Outputs: map[string]map[string]int64{}}
+		for addr, amount := range outputs {
+			e.Outputs[addr] = map[string]int64{balance.ColorIOTA.String(): amount}
+		}
+		return e
+	}
+	today := since.Add(time.Hour)
+	entries := []*journal.Entry{
+		entry(today, own, journal.StateConfirmed, map[string]int64{alice.String(): 10, own.String(): 90}),
+		entry(today, own, journal.StatePending, map[string]int64{bob.String(): 20}),
+		entry(today, own, journal.StateRejected, map[string]int64{bob.String(): 1000}),
+		entry(since.Add(-time.Hour), own, journal.StateConfirmed, map[string]int64{alice.String(): 1000}),
+		// posted by another identity of the same profile
+		entry(today, alice, journal.StateConfirmed, map[string]int64{bob.String(): 1000}),
+	}
+	owner := func(b58 string) bool { return b58 == own.String() }
+
+	spent := spentSince(entries, since, owner)
+	if got := spent[balance.ColorIOTA]; got != 30 {
+		t.Fatalf("spent = %d, want 30", got)
+	}
+}
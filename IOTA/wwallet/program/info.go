@@ -16,7 +16,7 @@ func infoCmd(args []string) {
 
 	hash, err := hashing.HashValueFromBase58(args[0])
 	check(err)
-	nodes := parseIntList(args[1])
+	nodes := config.ParseCommittee(args[1])
 
 	for _, host := range config.CommitteeApi(nodes) {
 		md, err := client.NewWaspClient(host).GetProgramMetadata(&hash)
@@ -34,6 +34,6 @@ func infoCmd(args []string) {
 
 func infoUsage() {
 	fmt.Printf("Usage: %s program info <program-hash> <nodes>\n", os.Args[0])
-	fmt.Printf("Example: %s program info aBcD...wXyZ '0,1,2,3'\n", os.Args[0])
+	fmt.Printf("Example: %s program info aBcD...wXyZ 'node0,node1,node2,node3'\n", os.Args[0])
 	os.Exit(1)
 }
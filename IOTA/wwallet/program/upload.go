@@ -18,7 +18,7 @@ func uploadCmd(args []string) {
 	check(err)
 	vmtype := args[1]
 	description := args[2]
-	nodes := parseIntList(args[3])
+	nodes := config.ParseCommittee(args[3])
 
 	for _, host := range config.CommitteeApi(nodes) {
 		hash, err := client.NewWaspClient(host).PutProgram(vmtype, description, code)
@@ -30,6 +30,6 @@ func uploadCmd(args []string) {
 
 func uploadUsage() {
 	fmt.Printf("Usage: %s program upload <filename> <vmtype> <description> <nodes>\n", os.Args[0])
-	fmt.Printf("Example: %s program upload program-code.bin wasm 'Example smart contract' '0,1,2,3'\n", os.Args[0])
+	fmt.Printf("Example: %s program upload program-code.bin wasm 'Example smart contract' 'node0,node1,node2,node3'\n", os.Args[0])
 	os.Exit(1)
 }
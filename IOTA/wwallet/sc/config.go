@@ -10,18 +10,25 @@ import (
 	"wasp/client/scclient"
 	waspapi "wasp/packages/apilib"
 	"wasp/packages/hashing"
+	"wasp/packages/kv"
 	"wasp/packages/registry"
+	"wasp/packages/sctransaction"
 	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/txinfo"
 
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
-	"github.com/spf13/viper"
 )
 
 type Config struct {
 	ShortName   string
 	Name        string
 	ProgramHash string
+	// RequestNames maps the request codes understood by the SC to readable
+	// names, for decoding requests.
+	RequestNames map[sctransaction.RequestCode]string
+	// RequestArgs decodes the request arguments understood by the SC.
+	RequestArgs map[kv.Key]txinfo.ArgDecoder
 
 	bootupData *registry.BootupData
 }
@@ -32,7 +39,7 @@ func (c *Config) MakeClient(sigScheme signaturescheme.SignatureScheme) *scclient
 		timeout = 1 * time.Minute
 	}
 	client := scclient.New(
-		config.GoshimmerClient(),
+		config.SpendingClient(),
 		client.NewWaspClient(config.WaspApi()),
 		c.Address(),
 		sigScheme,
@@ -55,14 +62,20 @@ func (c *Config) Href() string {
 	return "/" + c.ShortName
 }
 
-var DefaultCommittee = []int{0, 1, 2, 3}
+var DefaultCommittee = []string{
+	config.DefaultNodeName(0),
+	config.DefaultNodeName(1),
+	config.DefaultNodeName(2),
+	config.DefaultNodeName(3),
+}
 
-func (c *Config) SetCommittee(indexes []int) {
-	config.Set("sc."+c.Alias()+".committee", indexes)
+// SetCommittee stores the committee as node names, see `node add`.
+func (c *Config) SetCommittee(nodes []string) {
+	config.Set(config.SCConfigVar(c.Alias(), "committee"), nodes)
 }
 
-func (c *Config) Committee() []int {
-	r := viper.GetIntSlice("sc." + c.Alias() + ".committee")
+func (c *Config) Committee() []string {
+	r := config.Current().SelectedNetwork().SCSection(c.Alias()).Committee
 	if len(r) > 0 {
 		return r
 	}
@@ -70,11 +83,11 @@ func (c *Config) Committee() []int {
 }
 
 func (c *Config) SetQuorum(n uint16) {
-	config.Set("sc."+c.Alias()+".quorum", int(n))
+	config.Set(config.SCConfigVar(c.Alias(), "quorum"), int(n))
 }
 
 func (c *Config) Quorum() uint16 {
-	q := viper.GetInt("sc." + c.Alias() + ".quorum")
+	q := config.Current().SelectedNetwork().SCSection(c.Alias()).Quorum
 	if q != 0 {
 		return uint16(q)
 	}
@@ -90,7 +103,7 @@ func (c *Config) HandleSetCmd(args []string) {
 		c.PrintUsage("set <key> <value>")
 		os.Exit(1)
 	}
-	config.Set("sc."+c.Alias()+"."+args[0], args[1])
+	config.Set(config.SCConfigVar(c.Alias(), args[0]), args[1])
 }
 
 func (c *Config) usage(commands map[string]func([]string)) {
@@ -144,15 +157,25 @@ func (c *Config) Deploy(sigScheme signaturescheme.SignatureScheme) error {
 
 type DeployParams struct {
 	Quorum      uint16
-	Committee   []int
+	Committee   []string
 	Description string
 	ProgramHash string
 	SigScheme   signaturescheme.SignatureScheme
 }
 
 func Deploy(params *DeployParams) (*address.Address, error) {
+	if config.DryRun {
+		// DKG and the bootup data are stored on the committee nodes, so a
+		// dry run stops before contacting them.
+		fmt.Printf("[deploy] %s: program %s, quorum %d of %s\n",
+			params.Description, params.progHash(), params.Quorum, strings.Join(params.Committee, ","))
+		for i, host := range config.CommitteeApi(params.Committee) {
+			fmt.Printf("[deploy]   %s: %s\n", params.Committee[i], host)
+		}
+		return nil, config.ErrDryRun
+	}
 	scAddress, _, err := waspapi.CreateSC(waspapi.CreateSCParams{
-		Node:                  config.GoshimmerClient(),
+		Node:                  config.SpendingClient(),
 		CommitteeApiHosts:     config.CommitteeApi(params.Committee),
 		CommitteePeeringHosts: config.CommitteePeering(params.Committee),
 		AccessNodes:           []string{},
@@ -1,9 +1,12 @@
 package dwf
 
 import (
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
 	"wasp/packages/vm/examples/donatewithfeedback/dwfclient"
 	"wasp/packages/vm/examples/donatewithfeedback/dwfimpl"
 	"wasp/tools/wwallet/sc"
+	"wasp/tools/wwallet/txinfo"
 	"wasp/tools/wwallet/wallet"
 )
 
@@ -11,6 +14,14 @@ var Config = &sc.Config{
 	ShortName:   "dwf",
 	Name:        "DonateWithFeedback",
 	ProgramHash: dwfimpl.ProgramHash,
+	RequestNames: map[sctransaction.RequestCode]string{
+		dwfimpl.RequestDonate:  "donate",
+		dwfimpl.RequestHarvest: "harvest",
+	},
+	RequestArgs: map[kv.Key]txinfo.ArgDecoder{
+		dwfimpl.VarReqFeedback:   txinfo.StringArg,
+		dwfimpl.VarReqHarvestSum: txinfo.Int64Arg,
+	},
 }
 
 func Client() *dwfclient.DWFClient {
@@ -1,9 +1,7 @@
 package dwfcmd
 
 import (
-	"fmt"
-	"os"
-
+	"wasp/tools/wwallet/config"
 	"wasp/tools/wwallet/sc/dwf"
 )
 
@@ -32,8 +30,5 @@ func cmd(args []string) {
 }
 
 func check(err error) {
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
-	}
+	config.Check(err)
 }
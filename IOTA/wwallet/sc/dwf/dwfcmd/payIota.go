@@ -23,8 +23,7 @@ func payIotaCmd(args []string) {
 
 	feedback := ""
 
-	dwf.Client().Donate(int64(amount), feedback)
-
+	_, err = dwf.Client().Donate(int64(amount), feedback)
 	check(err)
-	fmt.Printf("Biglietto acquistato! Puoi salire sull'autobus")
+	fmt.Printf("Biglietto acquistato! Puoi salire sull'autobus\n")
 }
@@ -10,7 +10,6 @@ import (
 	clientutil "wasp/tools/wwallet/util"
 	"wasp/tools/wwallet/wallet"
 
-	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 )
 
@@ -20,10 +19,10 @@ func uploadCreditCmd(args []string) {
 		os.Exit(1)
 	}
 
-	wallet := wallet.Load()
-	persona := wallet.Address() //indirizzo destinatario
+	w := wallet.Load()
+	persona := w.Address() //indirizzo destinatario
 
-	scAdd, err := address.FromBase58(args[0]) //indirizzo mittente, devo vedere come mettere quello dello sc
+	scAdd, err := config.ResolveAddress(args[0]) //indirizzo mittente, devo vedere come mettere quello dello sc
 	check(err)
 
 	amount, err := strconv.Atoi(args[2])
@@ -32,13 +31,10 @@ func uploadCreditCmd(args []string) {
 	bals, err := config.GoshimmerClient().GetConfirmedAccountOutputs(&scAdd) //vede se lo Sc ha denaro sufficente
 	check(err)
 
-	vtxb, err := vtxbuilder.NewFromOutputBalances(bals)
-	check(err)
-
-	check(vtxb.MoveToAddress(persona, balance.ColorIOTA, int64(amount*10)))
-
-	tx := vtxb.Build(false)
-	tx.Sign(wallet.SignatureScheme()) //mettere la signatura dello sc
+	tx := wallet.BuildTransaction([]*wallet.AccountAddress{{Address: scAdd, Outputs: bals}}, func(vtxb *vtxbuilder.Builder) error {
+		return vtxb.MoveToAddress(persona, balance.ColorIOTA, int64(amount*10))
+	})
+	tx.Sign(w.SignatureScheme()) //mettere la signatura dello sc
 
 	clientutil.PostTransaction(tx) //vedere cosa fa... Sono rimasto qua
 }
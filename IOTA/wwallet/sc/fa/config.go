@@ -1,9 +1,12 @@
 package fa
 
 import (
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
 	"wasp/packages/vm/examples/fairauction"
 	"wasp/packages/vm/examples/fairauction/faclient"
 	"wasp/tools/wwallet/sc"
+	"wasp/tools/wwallet/txinfo"
 	"wasp/tools/wwallet/wallet"
 )
 
@@ -11,6 +14,19 @@ var Config = &sc.Config{
 	ShortName:   "fa",
 	Name:        "FairAuction",
 	ProgramHash: fairauction.ProgramHash,
+	RequestNames: map[sctransaction.RequestCode]string{
+		fairauction.RequestStartAuction:    "start auction",
+		fairauction.RequestFinalizeAuction: "finalize auction",
+		fairauction.RequestPlaceBid:        "place bid",
+		fairauction.RequestSetOwnerMargin:  "set owner margin",
+	},
+	RequestArgs: map[kv.Key]txinfo.ArgDecoder{
+		fairauction.VarReqAuctionColor:                txinfo.ColorArg,
+		fairauction.VarReqStartAuctionDescription:     txinfo.StringArg,
+		fairauction.VarReqStartAuctionDurationMinutes: txinfo.Int64Arg,
+		fairauction.VarReqStartAuctionMinimumBid:      txinfo.Int64Arg,
+		fairauction.VarReqOwnerMargin:                 txinfo.Int64Arg,
+	},
 }
 
 func Client() *faclient.FairAuctionClient {
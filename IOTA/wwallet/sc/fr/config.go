@@ -1,9 +1,12 @@
 package fr
 
 import (
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
 	"wasp/packages/vm/examples/fairroulette"
 	"wasp/packages/vm/examples/fairroulette/frclient"
 	"wasp/tools/wwallet/sc"
+	"wasp/tools/wwallet/txinfo"
 	"wasp/tools/wwallet/wallet"
 )
 
@@ -11,6 +14,16 @@ var Config = &sc.Config{
 	ShortName:   "fr",
 	Name:        "FairRoulette",
 	ProgramHash: fairroulette.ProgramHash,
+	RequestNames: map[sctransaction.RequestCode]string{
+		fairroulette.RequestPlaceBet:          "place bet",
+		fairroulette.RequestLockBets:          "lock bets",
+		fairroulette.RequestPlayAndDistribute: "play and distribute",
+		fairroulette.RequestSetPlayPeriod:     "set play period",
+	},
+	RequestArgs: map[kv.Key]txinfo.ArgDecoder{
+		fairroulette.ReqVarColor:         txinfo.Int64Arg,
+		fairroulette.ReqVarPlayPeriodSec: txinfo.Int64Arg,
+	},
 }
 
 func Client() *frclient.FairRouletteClient {
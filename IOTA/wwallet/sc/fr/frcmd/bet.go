@@ -1,10 +1,10 @@
 package frcmd
 
 import (
-	"fmt"
 	"os"
 	"strconv"
 
+	"wasp/tools/wwallet/config"
 	"wasp/tools/wwallet/sc/fr"
 )
 
@@ -24,8 +24,5 @@ func betCmd(args []string) {
 }
 
 func check(err error) {
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
-	}
+	config.Check(err)
 }
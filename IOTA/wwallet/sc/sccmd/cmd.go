@@ -3,9 +3,10 @@ package sccmd
 import (
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
+	"wasp/tools/wwallet/config"
+
 	"github.com/spf13/pflag"
 )
 
@@ -40,19 +41,6 @@ func usage() {
 	os.Exit(1)
 }
 
-func parseIntList(s string) []int {
-	committee := make([]int, 0)
-	for _, ns := range strings.Split(s, ",") {
-		n, err := strconv.Atoi(ns)
-		check(err)
-		committee = append(committee, n)
-	}
-	return committee
-}
-
 func check(err error) {
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
-	}
+	config.Check(err)
 }
@@ -15,7 +15,7 @@ func deployCmd(args []string) {
 		deployUsage()
 	}
 
-	committee := parseIntList(args[0])
+	committee := config.ParseCommittee(args[0])
 	quorum, err := strconv.Atoi(args[1])
 	check(err)
 	progHash := args[2]
@@ -35,11 +35,12 @@ func deployUsage() {
 	fmt.Printf("Usage: %s sc deploy <committee> <quorum> <program-hash> <description>\n", os.Args[0])
 	fmt.Printf("Example:\n")
 	fmt.Printf("  %s set %s '%s'\n", os.Args[0], config.GoshimmerApiConfigVar(), config.GoshimmerApi())
-	for i := 0; i < len(sc.DefaultCommittee); i++ {
-		fmt.Printf("  %s set %s '%s'\n", os.Args[0], config.CommitteeApiConfigVar(i), config.CommitteeApi(sc.DefaultCommittee)[i])
-		fmt.Printf("  %s set %s '%s'\n", os.Args[0], config.CommitteePeeringConfigVar(i), config.CommitteePeering(sc.DefaultCommittee)[i])
-		fmt.Printf("  %s set %s '%s'\n", os.Args[0], config.CommitteeNanomsgConfigVar(i), config.CommitteeNanomsg(sc.DefaultCommittee)[i])
+	for i, name := range sc.DefaultCommittee {
+		fmt.Printf("  %s node add %s '%s' '%s' '%s'\n", os.Args[0], name,
+			config.CommitteeApi(sc.DefaultCommittee)[i],
+			config.CommitteePeering(sc.DefaultCommittee)[i],
+			config.CommitteeNanomsg(sc.DefaultCommittee)[i])
 	}
-	fmt.Printf("  %s --sc=fr sc deploy '0,1,2,3' 3 'FNT6snmmEM28duSg7cQomafbJ5fs596wtuNRn18wfaAz' 'FairRoulette'\n", os.Args[0])
+	fmt.Printf("  %s --sc=fr sc deploy 'node0,node1,node2,node3' 3 'FNT6snmmEM28duSg7cQomafbJ5fs596wtuNRn18wfaAz' 'FairRoulette'\n", os.Args[0])
 	os.Exit(1)
 }
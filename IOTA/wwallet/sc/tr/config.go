@@ -1,18 +1,63 @@
 package tr
 
 import (
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
 	"wasp/packages/vm/examples/tokenregistry"
 	"wasp/packages/vm/examples/tokenregistry/trclient"
 	"wasp/tools/wwallet/sc"
+	"wasp/tools/wwallet/txinfo"
 	"wasp/tools/wwallet/wallet"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 )
 
 var Config = &sc.Config{
 	ShortName:   "tr",
 	Name:        "TokenRegistry",
 	ProgramHash: tokenregistry.ProgramHash,
+	RequestNames: map[sctransaction.RequestCode]string{
+		tokenregistry.RequestMintSupply:        "mint supply",
+		tokenregistry.RequestUpdateMetadata:    "update metadata",
+		tokenregistry.RequestTransferOwnership: "transfer ownership",
+	},
+	RequestArgs: map[kv.Key]txinfo.ArgDecoder{
+		tokenregistry.VarReqDescription:         txinfo.StringArg,
+		tokenregistry.VarReqUserDefinedMetadata: txinfo.BytesArg,
+	},
 }
 
 func Client() *trclient.TokenRegistryClient {
 	return trclient.NewClient(Config.MakeClient(wallet.Load().SignatureScheme()))
 }
+
+// Registry gives the wallet commands access to the TokenRegistry.
+var Registry wallet.TokenRegistry = registry{}
+
+type registry struct{}
+
+func (registry) Address() *address.Address {
+	if !Config.IsAvailable() {
+		return nil
+	}
+	return Config.Address()
+}
+
+// Lookup returns the TokenRegistry metadata of color, or nil if it is not
+// registered.
+func (registry) Lookup(color balance.Color) (*wallet.TokenInfo, error) {
+	tm, err := Client().Query(&color)
+	if err != nil {
+		return nil, err
+	}
+	if tm == nil {
+		return nil, nil
+	}
+	return &wallet.TokenInfo{
+		Description: tm.Description,
+		MintedBy:    tm.MintedBy.String(),
+		Supply:      tm.Supply,
+		Created:     tm.Created,
+	}, nil
+}
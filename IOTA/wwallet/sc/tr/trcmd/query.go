@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"wasp/packages/util"
+	"wasp/tools/wwallet/config"
 	"wasp/tools/wwallet/sc/tr"
 )
 
@@ -23,6 +24,10 @@ func queryCmd(args []string) {
 
 	fmt.Printf("Color: %s\n", color)
 	fmt.Printf("Supply: %d\n", tm.Supply)
+	if burned := config.BurnedSupply(color); burned > 0 {
+		fmt.Printf("Burned by this wallet: %d\n", burned)
+		fmt.Printf("Circulating supply: %d\n", tm.Supply-burned)
+	}
 	fmt.Printf("Minted by: %s\n", tm.MintedBy)
 	fmt.Printf("Owner: %s\n", tm.Owner)
 	fmt.Printf("Created: %s\n", time.Unix(0, tm.Created).UTC())
@@ -0,0 +1,53 @@
+package txcmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"wasp/tools/wwallet/config"
+
+	"github.com/spf13/pflag"
+)
+
+var rebroadcastAfter time.Duration
+
+func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet) {
+	commands["tx"] = cmd
+
+	fs := pflag.NewFlagSet("tx", pflag.ExitOnError)
+	fs.DurationVar(&rebroadcastAfter, "rebroadcast-after", 1*time.Minute, "tx watch: rebroadcast pending transactions not confirmed after this long")
+	flags.AddFlagSet(fs)
+}
+
+var subcmds = map[string]func([]string){
+	"pending": pendingCmd,
+	"watch":   watchCmd,
+	"inspect": inspectCmd,
+}
+
+func cmd(args []string) {
+	if len(args) < 1 {
+		usage()
+	}
+	subcmd, ok := subcmds[args[0]]
+	if !ok {
+		usage()
+	}
+	subcmd(args[1:])
+}
+
+func usage() {
+	cmdNames := make([]string, 0)
+	for k := range subcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s tx [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func check(err error) {
+	config.Check(err)
+}
@@ -0,0 +1,146 @@
+package txcmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/journal"
+	"wasp/tools/wwallet/sc"
+	"wasp/tools/wwallet/sc/dwf"
+	"wasp/tools/wwallet/sc/fa"
+	"wasp/tools/wwallet/sc/fr"
+	"wasp/tools/wwallet/sc/tr"
+	"wasp/tools/wwallet/txinfo"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var knownSCs = []*sc.Config{fr.Config, fa.Config, tr.Config, dwf.Config}
+
+func inspectCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s tx inspect <transaction-id|file>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	tx := loadTransaction(args[0])
+	txid := tx.ID()
+	confirmed, err := config.GoshimmerClient().GetConfirmedTransaction(&txid)
+	isConfirmed := err == nil && confirmed != nil
+
+	fmt.Printf("Transaction %s\n", txid)
+	fmt.Printf("  Essence hash: %x\n", txinfo.EssenceHash(tx))
+	fmt.Printf("  Confirmed: %v\n", isConfirmed)
+	fmt.Printf("  Signatures: %d, valid: %v\n", len(tx.Signatures()), tx.SignaturesValid())
+
+	scs := availableSCs()
+	var requests []*sctransaction.RequestBlock
+	if sctx, err := sctransaction.ParseValueTransaction(tx); err == nil {
+		requests = sctx.Requests()
+	}
+	requestTargets := make(map[address.Address]bool)
+	for _, req := range requests {
+		requestTargets[req.Address()] = true
+	}
+
+	fmt.Printf("  Inputs:\n")
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		fmt.Printf("    %s\n", outputID)
+		return true
+	})
+
+	fmt.Printf("  Outputs:\n")
+	tx.Outputs().ForEach(func(addr address.Address, bals []*balance.Balance) bool {
+		fmt.Printf("    %s%s:\n", addr, describeAddress(addr, scs, requestTargets[addr]))
+		for _, bal := range bals {
+			fmt.Printf("      %s: %d\n", bal.Color, bal.Value)
+		}
+		return true
+	})
+
+	if len(requests) == 0 {
+		return
+	}
+	fmt.Printf("  SC requests:\n")
+	for i, req := range requests {
+		fmt.Printf("    #%d to %s%s\n", i, req.Address(), describeAddress(req.Address(), scs, false))
+		code := fmt.Sprintf("%d", req.RequestCode())
+		if c, ok := scs[req.Address()]; ok {
+			if name, ok := c.RequestNames[req.RequestCode()]; ok {
+				code += " (" + name + ")"
+			}
+		}
+		fmt.Printf("      Request code: %s\n", code)
+		var decoders map[kv.Key]txinfo.ArgDecoder
+		if c, ok := scs[req.Address()]; ok {
+			decoders = c.RequestArgs
+		}
+		fmt.Printf("      Arguments:\n")
+		for _, arg := range txinfo.FormatArgs(req, decoders) {
+			fmt.Printf("        %s\n", arg)
+		}
+	}
+}
+
+// loadTransaction reads the transaction from a file written by
+// `send-funds --unsigned-out` or `sign`, or fetches it from the node, or
+// from the pending transactions if the node has not confirmed it.
+func loadTransaction(s string) *transaction.Transaction {
+	if b, err := ioutil.ReadFile(s); err == nil {
+		tx, _, err := transaction.FromBytes(b)
+		check(err)
+		return tx
+	}
+	txid, err := transaction.IDFromBase58(s)
+	check(err)
+	tx, err := config.GoshimmerClient().GetConfirmedTransaction(&txid)
+	if err == nil && tx != nil {
+		return tx
+	}
+	pending, perr := journal.ReadPending(config.PendingPath())
+	check(perr)
+	if p, ok := pending[txid.String()]; ok {
+		tx, err := p.Transaction()
+		check(err)
+		return tx
+	}
+	check(err)
+	check(fmt.Errorf("transaction %s not found", s))
+	return nil
+}
+
+// availableSCs maps the address of each known SC to its config. Each SC is
+// looked up under its own alias, regardless of --sc.
+func availableSCs() map[address.Address]*sc.Config {
+	ret := make(map[address.Address]*sc.Config)
+	for _, c := range knownSCs {
+		if addr := config.TrySCAddress(c.ShortName); addr != nil {
+			ret[*addr] = c
+		}
+	}
+	return ret
+}
+
+func describeAddress(addr address.Address, scs map[address.Address]*sc.Config, isRequest bool) string {
+	s := ""
+	if c, ok := scs[addr]; ok {
+		s = " (" + c.Name + ")"
+	} else {
+		for alias, b58 := range config.Contacts() {
+			if b58 == addr.String() {
+				s = " (" + alias + ")"
+				break
+			}
+		}
+	}
+	if isRequest {
+		s += " [SC request]"
+	}
+	return s
+}
@@ -0,0 +1,134 @@
+package txcmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/journal"
+	"wasp/tools/wwallet/util"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func pendingCmd(args []string) {
+	pending, err := journal.ReadPending(config.PendingPath())
+	check(err)
+
+	if len(pending) == 0 {
+		fmt.Printf("No pending transactions\n")
+		return
+	}
+	list := make([]*journal.Pending, 0, len(pending))
+	for _, p := range pending {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Posted.Before(list[j].Posted) })
+	for _, p := range list {
+		fmt.Printf("%s\n", p.TxID)
+		fmt.Printf("  Last posted: %s (%s ago)\n", p.Posted.Format(time.RFC3339), time.Since(p.Posted).Round(time.Second))
+		fmt.Printf("  Attempts: %d\n", p.Attempts)
+	}
+}
+
+type waitResult struct {
+	txid transaction.ID
+	err  error
+}
+
+// watchCmd waits until every pending transaction is either confirmed or
+// rejected, rebroadcasting the ones that take longer than --rebroadcast-after.
+func watchCmd(args []string) {
+	client := config.GoshimmerClient()
+	results := make(chan waitResult)
+	watching := make(map[string]bool)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		pending, err := journal.ReadPending(config.PendingPath())
+		check(err)
+		if len(pending) == 0 {
+			fmt.Printf("No pending transactions\n")
+			return
+		}
+
+		for txid, p := range pending {
+			if watching[txid] {
+				continue
+			}
+			tx, err := p.Transaction()
+			check(err)
+			watching[txid] = true
+			go func(txid transaction.ID) {
+				results <- waitResult{txid, client.WaitForConfirmation(txid)}
+			}(tx.ID())
+		}
+
+		select {
+		case r := <-results:
+			delete(watching, r.txid.String())
+			if r.err != nil {
+				logf("%s: %v", r.txid, r.err)
+			} else {
+				logf("%s: confirmed", r.txid)
+			}
+
+		case <-ticker.C:
+			for _, p := range pending {
+				if time.Since(p.Posted) >= rebroadcastAfter {
+					rebroadcast(p)
+				}
+			}
+		}
+	}
+}
+
+func rebroadcast(p *journal.Pending) {
+	tx, err := p.Transaction()
+	check(err)
+
+	client := config.GoshimmerClient()
+	if markIfConfirmed(tx.ID()) {
+		return
+	}
+
+	spent, err := util.SpentInputs(tx)
+	if err != nil {
+		logf("%s: %v", tx.ID(), err)
+		return
+	}
+	if len(spent) > 0 {
+		// the inputs are also spent if the transaction itself was confirmed
+		// in the meantime
+		if markIfConfirmed(tx.ID()) {
+			return
+		}
+		config.SetTransactionState(tx.ID(), journal.StateRejected)
+		logf("%s: rejected, input %s was spent by another transaction", tx.ID(), spent[0])
+		return
+	}
+
+	if err := client.PostTransaction(tx); err != nil {
+		logf("%s: rebroadcast failed: %v", tx.ID(), err)
+		return
+	}
+	logf("%s: rebroadcast (attempt %d)", tx.ID(), p.Attempts+1)
+}
+
+// markIfConfirmed records the transaction as confirmed if the node has
+// confirmed it.
+func markIfConfirmed(txid transaction.ID) bool {
+	confirmed, err := config.GoshimmerClient().GetConfirmedTransaction(&txid)
+	if err != nil || confirmed == nil {
+		return false
+	}
+	config.SetTransactionState(txid, journal.StateConfirmed)
+	logf("%s: confirmed", txid)
+	return true
+}
+
+func logf(format string, args ...interface{}) {
+	fmt.Printf("%s "+format+"\n", append([]interface{}{time.Now().Format(time.RFC3339)}, args...)...)
+}
@@ -0,0 +1,57 @@
+package txinfo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"wasp/packages/kv"
+	"wasp/packages/sctransaction"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+// ArgDecoder shows the value of a request argument.
+type ArgDecoder func(value []byte) string
+
+func Int64Arg(value []byte) string {
+	if len(value) != 8 {
+		return BytesArg(value)
+	}
+	return fmt.Sprintf("%d", int64(binary.LittleEndian.Uint64(value)))
+}
+
+func StringArg(value []byte) string {
+	return fmt.Sprintf("%q", value)
+}
+
+func ColorArg(value []byte) string {
+	color, _, err := balance.ColorFromBytes(value)
+	if err != nil {
+		return BytesArg(value)
+	}
+	return color.String()
+}
+
+func BytesArg(value []byte) string {
+	return fmt.Sprintf("0x%x", value)
+}
+
+// FormatArgs returns the arguments of a request as "key: value", sorted by
+// key, decoded with the decoder of each key, or as bytes if there is none.
+func FormatArgs(req *sctransaction.RequestBlock, decoders map[kv.Key]ArgDecoder) []string {
+	ret := make([]string, 0)
+	err := req.Args().Iterate("", func(key kv.Key, value []byte) bool {
+		decode, ok := decoders[key]
+		if !ok {
+			decode = BytesArg
+		}
+		ret = append(ret, fmt.Sprintf("%s: %s", key, decode(value)))
+		return true
+	})
+	if err != nil {
+		ret = append(ret, fmt.Sprintf("(cannot decode: %v)", err))
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,93 @@
+package txinfo
+
+import (
+	"fmt"
+
+	"wasp/packages/sctransaction"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Dump prints the inputs, the outputs by address and color, the SC requests
+// and the essence hash of a value transaction.
+func Dump(tx *transaction.Transaction) {
+	fmt.Printf("Transaction %s\n", tx.ID())
+	fmt.Printf("  Essence hash: %x\n", EssenceHash(tx))
+	fmt.Printf("  Inputs:\n")
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		fmt.Printf("    %s\n", outputID)
+		return true
+	})
+	fmt.Printf("  Outputs:\n")
+	totals := make(map[balance.Color]int64)
+	tx.Outputs().ForEach(func(addr address.Address, bals []*balance.Balance) bool {
+		fmt.Printf("    %s:\n", addr)
+		for _, bal := range bals {
+			fmt.Printf("      %s: %d\n", bal.Color, bal.Value)
+			totals[bal.Color] += bal.Value
+		}
+		return true
+	})
+	fmt.Printf("  Totals by color:\n")
+	for color, value := range totals {
+		fmt.Printf("    %s: %d\n", color, value)
+	}
+	DumpRequests(tx)
+	fmt.Printf("  Signed: %v\n", len(tx.Signatures()) > 0)
+}
+
+// DumpRequests prints the SC request blocks carried by the transaction, if
+// any.
+func DumpRequests(tx *transaction.Transaction) {
+	sctx, err := sctransaction.ParseValueTransaction(tx)
+	if err != nil || len(sctx.Requests()) == 0 {
+		return
+	}
+	fmt.Printf("  SC requests:\n")
+	for i, req := range sctx.Requests() {
+		fmt.Printf("    #%d to SC %s:\n", i, req.Address())
+		fmt.Printf("      Request code: %d\n", req.RequestCode())
+		fmt.Printf("      Arguments:\n")
+		for _, arg := range FormatArgs(req, nil) {
+			fmt.Printf("        %s\n", arg)
+		}
+	}
+}
+
+// EssenceHash is the hash of the signed part of the transaction, which does
+// not change when signatures are added.
+func EssenceHash(tx *transaction.Transaction) [32]byte {
+	return blake2b.Sum256(tx.EssenceBytes())
+}
+
+// DumpEssence decodes the inputs and outputs at the start of a transaction
+// essence, as received by a signature scheme.
+func DumpEssence(essence []byte) {
+	fmt.Printf("  Essence hash: %x\n", blake2b.Sum256(essence))
+	inputs, n, err := transaction.InputsFromBytes(essence)
+	if err != nil {
+		fmt.Printf("  Essence: %x\n", essence)
+		return
+	}
+	outputs, _, err := transaction.OutputsFromBytes(essence[n:])
+	if err != nil {
+		fmt.Printf("  Essence: %x\n", essence)
+		return
+	}
+	fmt.Printf("  Inputs:\n")
+	inputs.ForEach(func(outputID transaction.OutputID) bool {
+		fmt.Printf("    %s\n", outputID)
+		return true
+	})
+	fmt.Printf("  Outputs:\n")
+	outputs.ForEach(func(addr address.Address, bals []*balance.Balance) bool {
+		fmt.Printf("    %s:\n", addr)
+		for _, bal := range bals {
+			fmt.Printf("      %s: %d\n", bal.Color, bal.Value)
+		}
+		return true
+	})
+}
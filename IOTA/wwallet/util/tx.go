@@ -6,6 +6,8 @@ import (
 
 	"wasp/tools/wwallet/config"
 
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
 )
 
@@ -20,13 +22,41 @@ func WithTransaction(f func() (*transaction.Transaction, error)) {
 	check(err)
 
 	if config.WaitForCompletion {
-		check(config.GoshimmerClient().WaitForConfirmation(tx.ID()))
+		if err := config.GoshimmerClient().WaitForConfirmation(tx.ID()); err != nil {
+			fmt.Printf("error: %s\n", err)
+			fmt.Printf("transaction %s is still pending, run `%s tx watch` to track it\n", tx.ID(), os.Args[0])
+			config.Exit(1)
+		}
 	}
 }
 
+// SpentInputs returns the inputs of the transaction that are no longer among
+// the confirmed unspent outputs of their address.
+func SpentInputs(tx *transaction.Transaction) ([]transaction.OutputID, error) {
+	unspent := make(map[address.Address]map[transaction.OutputID]bool)
+	ret := make([]transaction.OutputID, 0)
+	var err error
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		addr := outputID.Address()
+		if _, ok := unspent[addr]; !ok {
+			var outs map[transaction.OutputID][]*balance.Balance
+			outs, err = config.GoshimmerClient().GetConfirmedAccountOutputs(&addr)
+			if err != nil {
+				return false
+			}
+			unspent[addr] = make(map[transaction.OutputID]bool)
+			for id := range outs {
+				unspent[addr][id] = true
+			}
+		}
+		if !unspent[addr][outputID] {
+			ret = append(ret, outputID)
+		}
+		return true
+	})
+	return ret, err
+}
+
 func check(err error) {
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
-	}
+	config.Check(err)
 }
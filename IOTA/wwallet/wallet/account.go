@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"fmt"
+
+	"wasp/packages/txutil/vtxbuilder"
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var accountMode bool
+var gapLimit int
+
+// AccountAddress is a seed index that holds confirmed outputs.
+type AccountAddress struct {
+	Index   uint64
+	Address address.Address
+	Outputs map[valuetransaction.OutputID][]*balance.Balance
+}
+
+// Discover scans the seed indices starting at 0 and returns the ones holding
+// confirmed outputs. The scan stops after gapLimit consecutive empty indices.
+// The indices of derived identities are skipped.
+func (w *Wallet) Discover() []*AccountAddress {
+	if w.IsWatchOnly() || w.derived {
+		addr := w.AddressAt(0)
+		return []*AccountAddress{{Address: addr, Outputs: fetchOutputs(addr)}}
+	}
+	ret := make([]*AccountAddress, 0)
+	gap := 0
+	for index := uint64(0); gap < gapLimit; index++ {
+		if !w.usable(index) {
+			continue
+		}
+		addr := w.AddressAt(index)
+		outs := fetchOutputs(addr)
+		if len(outs) == 0 {
+			gap++
+			continue
+		}
+		gap = 0
+		ret = append(ret, &AccountAddress{Index: index, Address: addr, Outputs: outs})
+	}
+	return ret
+}
+
+// Inputs returns the outputs to spend from: all discovered indices in account
+// mode, otherwise only the one selected with --address-index.
+func (w *Wallet) Inputs() []*AccountAddress {
+	if accountMode {
+		return w.Discover()
+	}
+	addr := w.Address()
+	return []*AccountAddress{{Index: uint64(addressIndex), Address: addr, Outputs: fetchOutputs(addr)}}
+}
+
+func fetchOutputs(addr address.Address) map[valuetransaction.OutputID][]*balance.Balance {
+	outs, err := config.GoshimmerClient().GetConfirmedAccountOutputs(&addr)
+	check(err)
+	return outs
+}
+
+func MergeOutputs(addrs []*AccountAddress) map[valuetransaction.OutputID][]*balance.Balance {
+	ret := make(map[valuetransaction.OutputID][]*balance.Balance)
+	for _, a := range addrs {
+		for id, bals := range a.Outputs {
+			ret[id] = bals
+		}
+	}
+	return ret
+}
+
+// BuildTransaction builds a transaction from the outputs of the given
+// addresses that are not reserved by another wwallet invocation, and reserves
+// the ones it uses.
+func BuildTransaction(inputs []*AccountAddress, f func(vtxb *vtxbuilder.Builder) error) *valuetransaction.Transaction {
+	tx, err := buildTransaction(inputs, f)
+	check(err)
+	return tx
+}
+
+// buildTransaction is BuildTransaction under the wallet lock, which must be
+// released before exiting on an error.
+func buildTransaction(inputs []*AccountAddress, f func(vtxb *vtxbuilder.Builder) error) (*valuetransaction.Transaction, error) {
+	unlock := config.LockWallet()
+	defer unlock()
+
+	outs, err := config.UnreservedOutputs(MergeOutputs(inputs))
+	if err != nil {
+		return nil, err
+	}
+	vtxb, err := vtxbuilder.NewFromOutputBalances(outs)
+	if err != nil {
+		return nil, err
+	}
+	if err := f(vtxb); err != nil {
+		return nil, err
+	}
+	tx := vtxb.Build(false)
+	if !config.DryRun {
+		if err := config.ReserveInputs(tx); err != nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// SignInputs signs the transaction with the key pair of every address that
+// appears among its inputs.
+func (w *Wallet) SignInputs(tx *valuetransaction.Transaction, addrs []*AccountAddress) {
+	indices := make(map[address.Address]uint64)
+	for _, a := range addrs {
+		indices[a.Address] = a.Index
+	}
+	signed := make(map[address.Address]bool)
+	tx.Inputs().ForEach(func(outputID valuetransaction.OutputID) bool {
+		addr := outputID.Address()
+		if signed[addr] {
+			return true
+		}
+		index, ok := indices[addr]
+		if !ok {
+			check(fmt.Errorf("input %s does not belong to the wallet", outputID))
+		}
+		tx.Sign(w.SignatureSchemeAt(index))
+		signed[addr] = true
+		return true
+	})
+}
+
+func accountCmd(args []string) {
+	addrs := Load().Discover()
+
+	for _, a := range addrs {
+		fmt.Printf("Address index %d\n", a.Index)
+		fmt.Printf("  Address: %s\n", a.Address)
+		fmt.Printf("  Balance:\n")
+		byColor(a.Outputs)
+	}
+	fmt.Printf("Account (%d used addresses, gap limit %d)\n", len(addrs), gapLimit)
+	fmt.Printf("  Balance:\n")
+	total := byColor(MergeOutputs(addrs))
+	fmt.Printf("    ------\n")
+	fmt.Printf("    Total: %d\n", total)
+}
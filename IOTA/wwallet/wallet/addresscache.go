@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// addressCache keeps the addresses and public keys derived from an encrypted
+// seed, so that the commands that only need them do not ask for the
+// passphrase. The entries are tagged with a hash of the keystore, so that
+// they are ignored once the seed changes.
+type addressCache struct {
+	keystore string
+	keys     map[uint64]*publicKeys
+}
+
+type publicKeys struct {
+	address   address.Address
+	publicKey ed25519.PublicKey
+}
+
+// addressCacheEntry is a line of the address cache file.
+type addressCacheEntry struct {
+	Keystore  string `json:"keystore"`
+	Index     uint64 `json:"index"`
+	Address   string `json:"address"`
+	PublicKey string `json:"publickey"`
+}
+
+func readAddressCache(ks *keystore) *addressCache {
+	h := blake2b.Sum256(ks.Ciphertext)
+	c := &addressCache{
+		keystore: base58.Encode(h[:16]),
+		keys:     make(map[uint64]*publicKeys),
+	}
+	f, err := os.Open(config.AddressCachePath())
+	if err != nil {
+		return c
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := &addressCacheEntry{}
+		if json.Unmarshal(scanner.Bytes(), e) != nil || e.Keystore != c.keystore {
+			continue
+		}
+		if k, err := e.decode(); err == nil {
+			c.keys[e.Index] = k
+		}
+	}
+	return c
+}
+
+func (e *addressCacheEntry) decode() (*publicKeys, error) {
+	addr, err := address.FromBase58(e.Address)
+	if err != nil {
+		return nil, err
+	}
+	b, err := base58.Decode(e.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, _, err := ed25519.PublicKeyFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return &publicKeys{addr, pubKey}, nil
+}
+
+func (c *addressCache) put(index uint64, k *publicKeys) {
+	c.keys[index] = k
+	b, err := json.Marshal(&addressCacheEntry{
+		Keystore:  c.keystore,
+		Index:     index,
+		Address:   k.address.String(),
+		PublicKey: base58.Encode(k.publicKey.Bytes()),
+	})
+	check(err)
+	f, err := os.OpenFile(config.AddressCachePath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err == nil {
+		_, err = f.Write(append(b, '\n'))
+		f.Close()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not write the address cache: %v\n", err)
+	}
+}
@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"wasp/packages/txutil/vtxbuilder"
+	"wasp/packages/util"
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+type payout struct {
+	Address address.Address
+	Color   balance.Color
+	Amount  int64
+}
+
+func sendBatchCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s send-batch <payouts.csv>\n", os.Args[0])
+		fmt.Printf("Each row of the file: <target-address|alias>,<color>,<amount>\n")
+		os.Exit(1)
+	}
+
+	payouts := readPayouts(args[0])
+	if len(payouts) == 0 {
+		check(fmt.Errorf("%s contains no payouts", args[0]))
+	}
+
+	inputs := sourceInputs()
+
+	for _, p := range payouts {
+		config.WarnIfUnknownAddress(p.Address)
+	}
+	totals := make(map[balance.Color]int64)
+	tx := BuildTransaction(inputs, func(vtxb *vtxbuilder.Builder) error {
+		for _, p := range payouts {
+			if err := vtxb.MoveToAddress(p.Address, p.Color, p.Amount); err != nil {
+				return err
+			}
+			totals[p.Color] += p.Amount
+		}
+		return nil
+	})
+
+	fmt.Printf("Batch of %d payouts\n", len(payouts))
+	fmt.Printf("  Totals by color:\n")
+	for color, value := range totals {
+		fmt.Printf("    %s: %d\n", color, value)
+	}
+
+	signOrWrite(tx, inputs)
+}
+
+func readPayouts(path string) []*payout {
+	f, err := os.Open(path)
+	check(err)
+	defer f.Close()
+
+	payouts, err := parsePayouts(f)
+	check(err)
+	return payouts
+}
+
+// parsePayouts reads the rows of a payouts CSV file, skipping an optional
+// header row that starts with "address".
+func parsePayouts(in io.Reader) ([]*payout, error) {
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = 3
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+
+	ret := make([]*payout, 0)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "address") {
+			continue
+		}
+		p, err := parsePayout(line, record)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func parsePayout(line int, record []string) (*payout, error) {
+	addr, err := config.ResolveAddress(record[0])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid address %s: %v", line, record[0], err)
+	}
+	color, err := util.ColorFromString(record[1])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: invalid color %s: %v", line, record[1], err)
+	}
+	amount, err := strconv.ParseInt(record[2], 10, 64)
+	if err != nil || amount <= 0 {
+		return nil, fmt.Errorf("line %d: invalid amount %s", line, record[2])
+	}
+	return &payout{Address: addr, Color: color, Amount: amount}, nil
+}
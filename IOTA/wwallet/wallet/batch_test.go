@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+func TestParsePayouts(t *testing.T) {
+	csv := "address,color,amount\n" +
+		"# comment\n" +
+		testAddress(0).String() + ",IOTA,100\n" +
+		testAddress(1).String() + ", IOTA, 5\n"
+	payouts, err := parsePayouts(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payouts) != 2 {
+		t.Fatalf("expected 2 payouts, got %d", len(payouts))
+	}
+	if payouts[0].Address != testAddress(0) || payouts[0].Color != balance.ColorIOTA || payouts[0].Amount != 100 {
+		t.Errorf("unexpected first payout: %+v", payouts[0])
+	}
+	if payouts[1].Address != testAddress(1) || payouts[1].Amount != 5 {
+		t.Errorf("unexpected second payout: %+v", payouts[1])
+	}
+}
+
+func TestParsePayoutsWithoutHeader(t *testing.T) {
+	payouts, err := parsePayouts(strings.NewReader(testAddress(0).String() + ",IOTA,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payouts) != 1 {
+		t.Fatalf("expected 1 payout, got %d", len(payouts))
+	}
+}
+
+func TestParsePayoutsErrors(t *testing.T) {
+	addr := testAddress(0).String()
+	cases := map[string]string{
+		"unknown header":   "recipient,color,amount\n" + addr + ",IOTA,1\n",
+		"short header":     "address,amount\n" + addr + ",IOTA,1\n",
+		"missing field":    addr + ",IOTA,1\n" + addr + ",IOTA\n",
+		"extra field":      addr + ",IOTA,1,memo\n",
+		"invalid address":  "nobody,IOTA,1\n",
+		"invalid color":    addr + ",not-a-color,1\n",
+		"invalid amount":   addr + ",IOTA,ten\n",
+		"zero amount":      addr + ",IOTA,0\n",
+		"negative amount":  addr + ",IOTA,-1\n",
+		"header not first": addr + ",IOTA,1\naddress,color,amount\n",
+	}
+	for name, csv := range cases {
+		if _, err := parsePayouts(strings.NewReader(csv)); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestParsePayoutsReportsLine(t *testing.T) {
+	csv := "address,color,amount\n" + testAddress(0).String() + ",IOTA,1\n" + testAddress(1).String() + ",IOTA,ten\n"
+	_, err := parsePayouts(strings.NewReader(csv))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected an error on line 3, got %v", err)
+	}
+}
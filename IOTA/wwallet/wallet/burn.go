@@ -0,0 +1,58 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"wasp/packages/txutil/vtxbuilder"
+	"wasp/tools/wwallet/config"
+	clientutil "wasp/tools/wwallet/util"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func burnCmd(registry TokenRegistry, args []string) {
+	if len(args) != 2 {
+		fmt.Printf("Usage: %s wallet burn <color> <amount>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	color := decodeColor(args[0])
+	if *color == balance.ColorIOTA || *color == balance.ColorNew {
+		check(fmt.Errorf("only colored tokens can be burned"))
+	}
+
+	amount, err := strconv.Atoi(args[1])
+	check(err)
+
+	inputs := sourceInputs()
+	target := ownAddress()
+
+	tx := BuildTransaction(inputs, func(vtxb *vtxbuilder.Builder) error {
+		return vtxb.EraseColor(target, *color, int64(amount))
+	})
+	if unsignedOut != "" || config.DryRun {
+		// a transaction broadcast later is not recorded as a burn
+		signOrWrite(tx, inputs)
+		return
+	}
+
+	Load().SignInputs(tx, inputs)
+	clientutil.WithTransaction(func() (*valuetransaction.Transaction, error) {
+		if err := config.GoshimmerClient().PostTransaction(tx); err != nil {
+			return nil, err
+		}
+		// recorded before waiting, the burn is dropped if the transaction is
+		// rejected
+		config.RecordBurn(tx.ID(), *color, int64(amount))
+		return tx, nil
+	})
+
+	forgetToken(registry, *color)
+	fmt.Printf("Burned %d tokens of color %s\n", amount, color)
+	if registry.Address() != nil {
+		fmt.Printf("Recorded in the journal: `%s tr query %s` shows the circulating supply\n", os.Args[0], color)
+	}
+}
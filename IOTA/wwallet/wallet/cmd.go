@@ -3,26 +3,92 @@ package wallet
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"wasp/tools/wwallet/config"
 
 	"github.com/spf13/pflag"
 )
 
-func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet) {
+// InitCommands registers the wallet commands. The TokenRegistry is used by
+// wallet tokens and wallet burn.
+func InitCommands(commands map[string]func([]string), flags *pflag.FlagSet, registry TokenRegistry) {
+	subcmds["tokens"] = func(args []string) { tokensCmd(registry, args) }
+	subcmds["burn"] = func(args []string) { burnCmd(registry, args) }
+
 	commands["init"] = initCmd
 	commands["address"] = addressCmd
 	commands["balance"] = balanceCmd
 	commands["mint"] = mintCmd
 	commands["send-funds"] = sendFundsCmd
 	commands["request-funds"] = requestFundsCmd
+	commands["account"] = accountCmd
+	commands["send-batch"] = sendBatchCmd
+	commands["sign"] = signCmd
+	commands["broadcast"] = broadcastCmd
+	commands["history"] = historyCmd
+	commands["wallet"] = walletCmd
+	commands["identity"] = identityCmd
+	commands["signer"] = signerCmd
 
 	fs := pflag.NewFlagSet("wallet", pflag.ExitOnError)
 	fs.IntVarP(&addressIndex, "address-index", "i", 0, "address index")
+	fs.StringVar(&asIdentity, "as", "", "name of the identity to act as")
+	fs.BoolVar(&accountMode, "account", false, "use all discovered address indices instead of --address-index")
+	fs.IntVar(&gapLimit, "gap-limit", 20, "number of consecutive empty address indices that ends address discovery")
+	fs.BoolVar(&encrypt, "encrypt", false, "init, wallet import: encrypt the seed with a passphrase")
+	fs.BoolVar(&force, "force", false, "wallet import: overwrite an existing seed")
+	fs.StringVar(&watchAddress, "address", "", "wallet import, identity create: watch-only address")
+	fs.StringVar(&watchPublicKey, "public-key", "", "wallet import, identity create: public key of a watch-only address")
+	fs.StringVar(&unsignedOut, "unsigned-out", "", "send-funds, send-batch, mint, wallet consolidate: write the unsigned transaction to this file instead of posting it (consolidate: one file per address, suffixed with .<index>)")
+	fs.StringVar(&fromAddress, "from", "", "send-funds, send-batch, mint: source address when building with --unsigned-out")
+	fs.StringVar(&colorFilter, "color", "", "history, wallet consolidate: only consider this color; wallet receive: requested color")
+	fs.Int64Var(&receiveAmount, "amount", 0, "wallet receive: requested amount")
+	fs.StringVar(&receiveMemo, "memo", "", "wallet receive: memo for the payer")
+	fs.StringVar(&receivePNG, "png", "", "wallet receive: write the QR code to this PNG file")
+	fs.StringVar(&untilBalance, "until-balance", "", "wallet watch: exit once the balance satisfies a condition, e.g. IOTA>=100")
+	fs.DurationVar(&pollInterval, "poll-interval", 5*time.Second, "wallet watch: how often to poll balances")
+	fs.IntVar(&maxInputs, "max-inputs", 0, "wallet consolidate: maximum number of outputs to merge (0 = all)")
+	fs.StringVar(&counterpartyFilter, "counterparty", "", "history: only show transactions involving this address or alias")
+	fs.StringVar(&sinceFilter, "since", "", "history: only show transactions from this date on (YYYY-MM-DD); received funds of unknown time are always shown")
+	fs.StringVar(&untilFilter, "until", "", "history: only show transactions up to this date (YYYY-MM-DD); received funds of unknown time are always shown")
 	flags.AddFlagSet(fs)
 }
 
-func check(err error) {
-	if err != nil {
-		fmt.Printf("error: %s\n", err)
-		os.Exit(1)
+var subcmds = map[string]func([]string){
+	"lock":              lockCmd,
+	"unlock":            unlockCmd,
+	"change-passphrase": changePassphraseCmd,
+	"export-mnemonic":   exportMnemonicCmd,
+	"import":            importCmd,
+	"consolidate":       consolidateCmd,
+	"sweep":             sweepCmd,
+	"receive":           receiveCmd,
+	"watch":             watchCmd,
+}
+
+func walletCmd(args []string) {
+	if len(args) < 1 {
+		usage()
+	}
+	subcmd, ok := subcmds[args[0]]
+	if !ok {
+		usage()
 	}
+	subcmd(args[1:])
+}
+
+func usage() {
+	cmdNames := make([]string, 0)
+	for k := range subcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s wallet [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func check(err error) {
+	config.Check(err)
 }
@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"wasp/packages/txutil/vtxbuilder"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var maxInputs int
+
+func consolidateCmd(args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s wallet consolidate [--color <color>] [--max-inputs <n>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	selected := make([]*AccountAddress, 0)
+	for _, a := range sourceInputs() {
+		outs := selectOutputs(a.Outputs)
+		if len(outs) < 2 {
+			fmt.Printf("Address index %d: nothing to consolidate\n", a.Index)
+			continue
+		}
+		selected = append(selected, &AccountAddress{Index: a.Index, Address: a.Address, Outputs: outs})
+	}
+	for _, a := range selected {
+		fmt.Printf("Address index %d: consolidating %d outputs\n", a.Index, len(a.Outputs))
+		tx := BuildTransaction([]*AccountAddress{a}, moveAll(a.Address, a.Outputs))
+		signOrWriteTo(tx, []*AccountAddress{a}, consolidateOut(a.Index, len(selected)))
+	}
+}
+
+// consolidateOut returns the file to write the unsigned transaction of the
+// given address index to: with more than one transaction each one gets its
+// own file, suffixed with the address index.
+func consolidateOut(index uint64, txs int) string {
+	if unsignedOut == "" || txs == 1 {
+		return unsignedOut
+	}
+	return fmt.Sprintf("%s.%d", unsignedOut, index)
+}
+
+// selectOutputs returns the outputs holding the --color given (any color if
+// not set), smallest first, limited to --max-inputs.
+func selectOutputs(outs map[valuetransaction.OutputID][]*balance.Balance) map[valuetransaction.OutputID][]*balance.Balance {
+	var color *balance.Color
+	if colorFilter != "" {
+		color = decodeColor(colorFilter)
+	}
+	type candidate struct {
+		id    valuetransaction.OutputID
+		value int64
+	}
+	candidates := make([]candidate, 0)
+	for id, bals := range outs {
+		var value int64
+		found := color == nil
+		for _, bal := range bals {
+			value += bal.Value
+			if color != nil && bal.Color == *color {
+				found = true
+			}
+		}
+		if found {
+			candidates = append(candidates, candidate{id, value})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].value < candidates[j].value })
+	if maxInputs > 0 && len(candidates) > maxInputs {
+		candidates = candidates[:maxInputs]
+	}
+	ret := make(map[valuetransaction.OutputID][]*balance.Balance)
+	for _, c := range candidates {
+		ret[c.id] = outs[c.id]
+	}
+	return ret
+}
+
+func sweepCmd(args []string) {
+	if len(args) != 2 {
+		fmt.Printf("Usage: %s wallet sweep <from-index> <to-index>\n", os.Args[0])
+		os.Exit(1)
+	}
+	from, err := strconv.ParseUint(args[0], 10, 64)
+	check(err)
+	to, err := strconv.ParseUint(args[1], 10, 64)
+	check(err)
+
+	wallet := Load()
+	source := wallet.AddressAt(from)
+	outs := fetchOutputs(source)
+	if len(outs) == 0 {
+		fmt.Printf("Address index %d is empty\n", from)
+		return
+	}
+	target := wallet.AddressAt(to)
+	fmt.Printf("Sweeping address index %d (%s) into index %d (%s)\n", from, source, to, target)
+
+	inputs := []*AccountAddress{{Index: from, Address: source, Outputs: outs}}
+	tx := BuildTransaction(inputs, moveAll(target, outs))
+	signOrWrite(tx, inputs)
+}
+
+// moveAll moves the whole balance of the outputs to the target address.
+func moveAll(target address.Address, outs map[valuetransaction.OutputID][]*balance.Balance) func(vtxb *vtxbuilder.Builder) error {
+	totals := make(map[balance.Color]int64)
+	for _, bals := range outs {
+		for _, bal := range bals {
+			totals[bal.Color] += bal.Value
+		}
+	}
+	return func(vtxb *vtxbuilder.Builder) error {
+		for color, value := range totals {
+			if err := vtxb.MoveToAddress(target, color, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
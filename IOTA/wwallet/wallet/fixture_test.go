@@ -0,0 +1,18 @@
+package wallet
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+)
+
+// testSeed returns the seed shared by the tests of this package.
+func testSeed() []byte {
+	return bytes.Repeat([]byte{0x42}, seedEntropyLen)
+}
+
+// testAddress returns the address at the given index of testSeed.
+func testAddress(index uint64) address.Address {
+	return seed.NewSeed(testSeed()).Address(index).Address
+}
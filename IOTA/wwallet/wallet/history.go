@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+const dateLayout = "2006-01-02"
+
+var colorFilter string
+var counterpartyFilter string
+var sinceFilter string
+var untilFilter string
+
+func historyCmd(args []string) {
+	entries, err := journal.Read(config.JournalPath())
+	check(err)
+
+	// the journal of the profile is shared by its identities
+	entries = postedBy(Load(), entries)
+	inJournal := make(map[string]bool)
+	for _, e := range entries {
+		inJournal[e.TxID] = true
+		if e.State == journal.StatePending && isConfirmed(e.TxID) {
+			e.State = journal.StateConfirmed
+		}
+	}
+	entries = append(entries, ledgerEntries(inJournal)...)
+
+	filter := historyFilter()
+	for _, e := range entries {
+		if filter(e) {
+			dumpEntry(e)
+		}
+	}
+}
+
+// postedBy returns the entries of the transactions that spend outputs of the
+// wallet.
+func postedBy(w *Wallet, entries []*journal.Entry) []*journal.Entry {
+	ret := make([]*journal.Entry, 0, len(entries))
+	for _, e := range entries {
+		for _, in := range e.Inputs {
+			if w.Owns(in) {
+				ret = append(ret, e)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// ledgerEntries returns the transactions that created the current confirmed
+// outputs of the wallet and that are not in the journal, i.e. incoming funds.
+// Outputs that were already spent are not found this way, and the ledger does
+// not tell when the transactions were made, so the entries have no Time.
+func ledgerEntries(inJournal map[string]bool) []*journal.Entry {
+	byTx := make(map[string]*journal.Entry)
+	for _, a := range Load().Inputs() {
+		for outputID, bals := range a.Outputs {
+			txid := outputID.TransactionID().String()
+			if inJournal[txid] {
+				continue
+			}
+			e, ok := byTx[txid]
+			if !ok {
+				e = &journal.Entry{
+					TxID:    txid,
+					Kind:    "received",
+					Outputs: make(map[string]map[string]int64),
+					State:   journal.StateConfirmed,
+				}
+				byTx[txid] = e
+			}
+			byColor := make(map[string]int64)
+			for _, bal := range bals {
+				byColor[bal.Color.String()] += bal.Value
+			}
+			e.Outputs[a.Address.String()] = byColor
+		}
+	}
+	ret := make([]*journal.Entry, 0, len(byTx))
+	for _, e := range byTx {
+		ret = append(ret, e)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].TxID < ret[j].TxID })
+	return ret
+}
+
+func isConfirmed(txidb58 string) bool {
+	txid, err := valuetransaction.IDFromBase58(txidb58)
+	if err != nil {
+		return false
+	}
+	tx, err := config.GoshimmerClient().GetConfirmedTransaction(&txid)
+	return err == nil && tx != nil
+}
+
+func historyFilter() func(e *journal.Entry) bool {
+	var color string
+	if colorFilter != "" {
+		color = decodeColor(colorFilter).String()
+	}
+	var counterparty string
+	if counterpartyFilter != "" {
+		counterparty = config.MustResolveAddress(counterpartyFilter).String()
+	}
+	since := parseDate(sinceFilter)
+	until := parseDate(untilFilter)
+	if !until.IsZero() {
+		until = until.AddDate(0, 0, 1)
+	}
+
+	return func(e *journal.Entry) bool {
+		if color != "" && !hasColor(e, color) {
+			return false
+		}
+		if counterparty != "" && !hasAddress(e, counterparty) {
+			return false
+		}
+		if e.Time.IsZero() {
+			// entries taken from the ledger have no known time, keep them
+			// rather than dropping them from every date range
+			return true
+		}
+		if !since.IsZero() && e.Time.Before(since) {
+			return false
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			return false
+		}
+		return true
+	}
+}
+
+func parseDate(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(dateLayout, s)
+	check(err)
+	return t
+}
+
+func hasColor(e *journal.Entry, color string) bool {
+	for _, byColor := range e.Outputs {
+		if _, ok := byColor[color]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAddress(e *journal.Entry, addr string) bool {
+	for _, in := range e.Inputs {
+		if in == addr {
+			return true
+		}
+	}
+	_, ok := e.Outputs[addr]
+	return ok
+}
+
+func dumpEntry(e *journal.Entry) {
+	when := "(time unknown)"
+	if !e.Time.IsZero() {
+		when = e.Time.Format(time.RFC3339)
+	}
+	fmt.Printf("%s %s %s (%s)\n", when, e.TxID, e.Kind, e.State)
+	for addr, byColor := range e.Outputs {
+		fmt.Printf("  -> %s\n", addr)
+		for color, amount := range byColor {
+			if color == balance.ColorNew.String() {
+				color = "new color"
+			}
+			fmt.Printf("       %s: %d\n", color, amount)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+func TestHistoryFilter(t *testing.T) {
+	colored := balance.Color{1, 2, 3}
+	from, to, other := testAddress(0).String(), testAddress(1).String(), testAddress(2).String()
+	entry := func(txid string, at time.Time, color balance.Color) *journal.Entry {
+		return &journal.Entry{
+			TxID:    txid,
+			Time:    at,
+			Inputs:  []string{from},
+			Outputs: map[string]map[string]int64{to: {color.String(): 1}},
+		}
+	}
+	entries := []*journal.Entry{
+		entry("jan1", time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), balance.ColorIOTA),
+		entry("jan2", time.Date(2020, 1, 2, 23, 59, 0, 0, time.UTC), colored),
+		entry("jan3", time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC), balance.ColorIOTA),
+		// taken from the ledger, the time is unknown
+		{TxID: "ledger", Outputs: map[string]map[string]int64{from: {balance.ColorIOTA.String(): 5}}},
+	}
+
+	defer func() { colorFilter, counterpartyFilter, sinceFilter, untilFilter = "", "", "", "" }()
+	for name, tc := range map[string]struct {
+		color, counterparty, since, until string
+		want                              []string
+	}{
+		"no filter":          {"", "", "", "", []string{"jan1", "jan2", "jan3", "ledger"}},
+		"since":              {"", "", "2020-01-02", "", []string{"jan2", "jan3", "ledger"}},
+		"until is inclusive": {"", "", "", "2020-01-02", []string{"jan1", "jan2", "ledger"}},
+		"single day":         {"", "", "2020-01-02", "2020-01-02", []string{"jan2", "ledger"}},
+		"color":              {colored.String(), "", "", "", []string{"jan2"}},
+		"counterparty input": {"", from, "", "", []string{"jan1", "jan2", "jan3", "ledger"}},
+		"counterparty out":   {"", to, "2020-01-03", "", []string{"jan3"}},
+		"other counterparty": {"", other, "", "", nil},
+	} {
+		colorFilter, counterpartyFilter, sinceFilter, untilFilter = tc.color, tc.counterparty, tc.since, tc.until
+		filter := historyFilter()
+		var got []string
+		for _, e := range entries {
+			if filter(e) {
+				got = append(got, e.TxID)
+			}
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: got %v, want %v", name, got, tc.want)
+				break
+			}
+		}
+	}
+}
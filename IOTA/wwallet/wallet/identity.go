@@ -0,0 +1,232 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultIdentity     = config.DefaultIdentity
+	identityConfigVar   = "identity"
+	identitiesConfigVar = "identities"
+)
+
+var asIdentity string
+
+// creating is the identity being created by `identity create`, which may be
+// selected before it exists.
+var creating string
+
+// IdentityName returns the identity selected with --as, or with
+// `identity use`, or the default one. It fails if there is no such identity.
+func IdentityName() string {
+	name := asIdentity
+	if name == "" {
+		name = config.Current().Identity
+	}
+	if name == "" {
+		return defaultIdentity
+	}
+	name = strings.ToLower(name)
+	if name != creating && !identityExists(name) {
+		check(fmt.Errorf("no identity named %s; create it with `%s identity create %s`", name, os.Args[0], name))
+	}
+	return name
+}
+
+func identityPrefix(name string) string {
+	if name == defaultIdentity {
+		return "wallet"
+	}
+	return identitiesConfigVar + "." + name
+}
+
+// derivedIndex returns the index on the default seed of an identity created
+// as a derivation path instead of with its own seed.
+func derivedIndex(name string) (uint64, bool) {
+	if name == defaultIdentity {
+		return 0, false
+	}
+	s := config.Current().IdentitySection(name).Index
+	if s == "" {
+		return 0, false
+	}
+	index, err := strconv.ParseUint(s, 10, 64)
+	check(err)
+	return index, true
+}
+
+// walletConfigVar returns the config key of a wallet setting for the
+// identity that holds the seed of the current one.
+func walletConfigVar(key string) string {
+	name := IdentityName()
+	if _, ok := derivedIndex(name); ok {
+		name = defaultIdentity
+	}
+	return identityPrefix(name) + "." + key
+}
+
+// walletSection returns the settings of the identity that holds the seed of
+// the current one.
+func walletSection() *config.Wallet {
+	name := IdentityName()
+	if _, ok := derivedIndex(name); ok {
+		name = defaultIdentity
+	}
+	return &config.Current().IdentitySection(name).Wallet
+}
+
+// claimedIndices returns the indices of the default seed used by derived
+// identities, by identity name.
+func claimedIndices() map[uint64]string {
+	ret := make(map[uint64]string)
+	for _, name := range Identities() {
+		if index, ok := derivedIndex(name); ok {
+			ret[index] = name
+		}
+	}
+	return ret
+}
+
+func checkNotDerived() {
+	if _, ok := derivedIndex(IdentityName()); ok {
+		check(fmt.Errorf("identity %s is derived from the default seed and has no seed of its own", IdentityName()))
+	}
+}
+
+func Identities() []string {
+	ret := []string{defaultIdentity}
+	names := make([]string, 0)
+	for name := range config.Current().Identities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append(ret, names...)
+}
+
+func identityExists(name string) bool {
+	for _, n := range Identities() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+var identitySubcmds = map[string]func([]string){
+	"create": identityCreateCmd,
+	"list":   identityListCmd,
+	"use":    identityUseCmd,
+}
+
+func identityCmd(args []string) {
+	if len(args) < 1 {
+		identityUsage()
+	}
+	subcmd, ok := identitySubcmds[args[0]]
+	if !ok {
+		identityUsage()
+	}
+	subcmd(args[1:])
+}
+
+func identityUsage() {
+	cmdNames := make([]string, 0)
+	for k := range identitySubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s identity [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func identityCreateCmd(args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Printf("Usage: %s identity create <name> [<address-index>]\n", os.Args[0])
+		fmt.Printf("       %s identity create <name> --address <address> | --public-key <public-key>\n", os.Args[0])
+		fmt.Printf("Without an address index, the identity gets its own seed.\n")
+		fmt.Printf("With an address index, it uses that index of the default seed.\n")
+		fmt.Printf("With --address or --public-key, it is watch-only.\n")
+		os.Exit(1)
+	}
+	name := strings.ToLower(args[0])
+	if strings.Contains(name, ".") {
+		check(fmt.Errorf("identity name cannot contain '.'"))
+	}
+	if identityExists(name) {
+		check(fmt.Errorf("identity %s already exists", name))
+	}
+
+	creating = name
+	switch {
+	case watchAddress != "" || watchPublicKey != "":
+		if len(args) != 1 {
+			check(fmt.Errorf("a watch-only identity has no address index"))
+		}
+		asIdentity = name
+		importWatchOnly()
+	case len(args) == 2:
+		index, err := strconv.ParseUint(args[1], 10, 64)
+		check(err)
+		if other, ok := claimedIndices()[index]; ok {
+			check(fmt.Errorf("address index %d is already used by identity %s", index, other))
+		}
+		viper.Set(identityPrefix(name)+".index", strconv.FormatUint(index, 10))
+		check(config.WriteConfig())
+		fmt.Printf("Address index %d of the default seed now belongs to %s and is no longer used by the default identity\n", index, name)
+	default:
+		asIdentity = name
+		writeSeed(seed.NewSeed().Bytes(), encrypt)
+	}
+	fmt.Printf("Identity %s created\n", name)
+}
+
+func identityListCmd(args []string) {
+	current := IdentityName()
+	for _, name := range Identities() {
+		marker := " "
+		if name == current {
+			marker = "*"
+		}
+		fmt.Printf("%s %s: %s\n", marker, name, describeIdentity(name))
+	}
+}
+
+func describeIdentity(name string) string {
+	if index, ok := derivedIndex(name); ok {
+		return fmt.Sprintf("default seed, address index %d", index)
+	}
+	w := config.Current().IdentitySection(name).Wallet
+	switch {
+	case w.Signer.Socket != "":
+		return "external signer at " + w.Signer.Socket
+	case w.Keystore.Ciphertext != "":
+		return "encrypted seed"
+	case w.Seed != "":
+		return "seed"
+	case w.Watch.Address != "":
+		return "watch-only " + w.Watch.Address
+	}
+	return "no seed"
+}
+
+func identityUseCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s identity use <name>\n", os.Args[0])
+		os.Exit(1)
+	}
+	name := strings.ToLower(args[0])
+	if !identityExists(name) {
+		check(fmt.Errorf("no identity named %s", name))
+	}
+	viper.Set(identityConfigVar, name)
+	check(config.WriteConfig())
+}
@@ -11,10 +11,21 @@ import (
 
 func addressCmd(args []string) {
 	wallet := Load()
-	kp := wallet.KeyPair()
-	fmt.Printf("Address index %d\n", addressIndex)
-	fmt.Printf("  Private key: %s\n", kp.PrivateKey)
-	fmt.Printf("  Public key:  %s\n", kp.PublicKey)
+	if wallet.IsWatchOnly() {
+		fmt.Printf("Watch-only address\n")
+	} else {
+		fmt.Printf("Address index %d\n", addressIndex)
+	}
+	if _, ok := wallet.signer.(*seedSigner); ok {
+		if isEncrypted() {
+			fmt.Printf("  Private key: (encrypted)\n")
+		} else {
+			fmt.Printf("  Private key: %s\n", wallet.KeyPair().PrivateKey)
+		}
+	}
+	if pubKey := wallet.PublicKeyAt(uint64(addressIndex)); pubKey != nil {
+		fmt.Printf("  Public key:  %s\n", pubKey)
+	}
 	fmt.Printf("  Address:     %s\n", wallet.Address())
 }
 
@@ -0,0 +1,195 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"os"
+
+	"wasp/tools/wwallet/config"
+
+	"github.com/mr-tron/base58"
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	passphraseEnvVar    = "WWALLET_PASSPHRASE"
+	newPassphraseEnvVar = "WWALLET_NEW_PASSPHRASE"
+
+	keystoreKDF = "scrypt"
+	scryptN     = 1 << 15
+	scryptR     = 8
+	scryptP     = 1
+	keyLen      = 32
+	saltLen     = 32
+)
+
+// keystore is the passphrase-protected form of the wallet seed, stored
+// under wallet.keystore (or identities.<name>.keystore): the AES-256-GCM key
+// is derived with scrypt.
+type keystore struct {
+	N          int
+	R          int
+	P          int
+	Salt       []byte
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+func encryptSeed(seedBytes []byte, passphrase []byte) (*keystore, error) {
+	ks := &keystore{
+		N:    scryptN,
+		R:    scryptR,
+		P:    scryptP,
+		Salt: make([]byte, saltLen),
+	}
+	if _, err := rand.Read(ks.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := ks.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	ks.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(ks.Nonce); err != nil {
+		return nil, err
+	}
+	ks.Ciphertext = aead.Seal(nil, ks.Nonce, seedBytes, []byte(keystoreKDF))
+	return ks, nil
+}
+
+func (ks *keystore) decrypt(passphrase []byte) ([]byte, error) {
+	aead, err := ks.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	seedBytes, err := aead.Open(nil, ks.Nonce, ks.Ciphertext, []byte(keystoreKDF))
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted keystore")
+	}
+	return seedBytes, nil
+}
+
+func (ks *keystore) aead(passphrase []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, ks.Salt, ks.N, ks.R, ks.P, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func readKeystore() *keystore {
+	ks := walletSection().Keystore
+	if ks.Ciphertext == "" {
+		return nil
+	}
+	if ks.Kdf != keystoreKDF {
+		check(fmt.Errorf("unsupported keystore kdf: %s", ks.Kdf))
+	}
+	return &keystore{
+		N:          ks.N,
+		R:          ks.R,
+		P:          ks.P,
+		Salt:       decodeKeystoreField(ks.Salt),
+		Nonce:      decodeKeystoreField(ks.Nonce),
+		Ciphertext: decodeKeystoreField(ks.Ciphertext),
+	}
+}
+
+func keystoreConfigVar(name string) string {
+	return walletConfigVar("keystore." + name)
+}
+
+func decodeKeystoreField(b58 string) []byte {
+	b, err := base58.Decode(b58)
+	check(err)
+	return b
+}
+
+func (ks *keystore) set() {
+	viper.Set(keystoreConfigVar("kdf"), keystoreKDF)
+	viper.Set(keystoreConfigVar("n"), ks.N)
+	viper.Set(keystoreConfigVar("r"), ks.R)
+	viper.Set(keystoreConfigVar("p"), ks.P)
+	viper.Set(keystoreConfigVar("salt"), base58.Encode(ks.Salt))
+	viper.Set(keystoreConfigVar("nonce"), base58.Encode(ks.Nonce))
+	viper.Set(keystoreConfigVar("ciphertext"), base58.Encode(ks.Ciphertext))
+}
+
+// writeSeed stores the seed in the config file, either in plaintext or
+// encrypted with a new passphrase, and removes the other representation.
+func writeSeed(seedBytes []byte, encrypt bool) {
+	unset := []string{walletConfigVar("watch")}
+	if encrypt {
+		ks, err := encryptSeed(seedBytes, newPassphrase())
+		check(err)
+		ks.set()
+		unset = append(unset, walletConfigVar("seed"))
+	} else {
+		viper.Set(walletConfigVar("seed"), base58.Encode(seedBytes))
+		unset = append(unset, walletConfigVar("keystore"))
+	}
+	config.Unset(unset...)
+	if !encrypt {
+		fmt.Fprintf(os.Stderr, "warning: wallet seed is stored in plaintext, run `%s wallet lock` to encrypt it\n", os.Args[0])
+	}
+}
+
+// readSeed returns the raw seed bytes, asking for the passphrase if the
+// seed is encrypted.
+func readSeed() []byte {
+	if ks := readKeystore(); ks != nil {
+		seedBytes, err := ks.decrypt(passphrase(passphraseEnvVar, "Passphrase: "))
+		check(err)
+		return seedBytes
+	}
+	seedb58 := walletSection().Seed
+	if len(seedb58) == 0 {
+		check(fmt.Errorf("call `init` first"))
+	}
+	seedBytes, err := base58.Decode(seedb58)
+	check(err)
+	return seedBytes
+}
+
+func hasSeed() bool {
+	return walletSection().Seed != "" || isEncrypted()
+}
+
+func isEncrypted() bool {
+	return readKeystore() != nil
+}
+
+func passphrase(envVar string, prompt string) []byte {
+	if p, ok := os.LookupEnv(envVar); ok {
+		return []byte(p)
+	}
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		check(fmt.Errorf("passphrase required: set %s or run interactively", envVar))
+	}
+	fmt.Print(prompt)
+	p, err := terminal.ReadPassword(fd)
+	fmt.Println()
+	check(err)
+	return p
+}
+
+func newPassphrase() []byte {
+	p := passphrase(newPassphraseEnvVar, "New passphrase: ")
+	if len(p) == 0 {
+		check(fmt.Errorf("passphrase cannot be empty"))
+	}
+	if !bytes.Equal(p, passphrase(newPassphraseEnvVar, "Repeat new passphrase: ")) {
+		check(fmt.Errorf("passphrases do not match"))
+	}
+	return p
+}
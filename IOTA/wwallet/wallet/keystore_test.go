@@ -0,0 +1,45 @@
+package wallet
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	seedBytes := testSeed()
+	ks, err := encryptSeed(seedBytes, []byte("correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(ks.Ciphertext, seedBytes) {
+		t.Fatal("ciphertext contains the seed")
+	}
+	decrypted, err := ks.decrypt([]byte("correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decrypted, seedBytes) {
+		t.Fatalf("seed mismatch: %x != %x", decrypted, seedBytes)
+	}
+}
+
+func TestKeystoreRejectsWrongPassphrase(t *testing.T) {
+	ks, err := encryptSeed(testSeed(), []byte("correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.decrypt([]byte("battery staple")); err == nil {
+		t.Fatal("decrypted with a wrong passphrase")
+	}
+}
+
+func TestKeystoreRejectsTamperedCiphertext(t *testing.T) {
+	ks, err := encryptSeed(testSeed(), []byte("correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks.Ciphertext[0] ^= 1
+	if _, err := ks.decrypt([]byte("correct horse")); err == nil {
+		t.Fatal("decrypted a tampered keystore")
+	}
+}
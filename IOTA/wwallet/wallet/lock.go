@@ -0,0 +1,30 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+)
+
+func lockCmd(args []string) {
+	if isEncrypted() {
+		check(fmt.Errorf("wallet seed is already encrypted"))
+	}
+	writeSeed(readSeed(), true)
+	fmt.Printf("Wallet seed encrypted\n")
+}
+
+func unlockCmd(args []string) {
+	if !isEncrypted() {
+		check(fmt.Errorf("wallet seed is not encrypted"))
+	}
+	writeSeed(readSeed(), false)
+	fmt.Printf("Wallet seed stored in plaintext\n")
+}
+
+func changePassphraseCmd(args []string) {
+	if !isEncrypted() {
+		check(fmt.Errorf("wallet seed is not encrypted, call `%s wallet lock` first", os.Args[0]))
+	}
+	writeSeed(readSeed(), true)
+	fmt.Printf("Passphrase changed\n")
+}
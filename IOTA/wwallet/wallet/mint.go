@@ -7,7 +7,8 @@ import (
 
 	"wasp/packages/txutil/vtxbuilder"
 	"wasp/tools/wwallet/config"
-	"wasp/tools/wwallet/util"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 )
 
 func mintCmd(args []string) {
@@ -16,15 +17,18 @@ func mintCmd(args []string) {
 		os.Exit(1)
 	}
 
-	wallet := Load()
-
 	amount, err := strconv.Atoi(args[0])
 	check(err)
 
-	tx, err := vtxbuilder.NewColoredTokensTransaction(config.GoshimmerClient(), wallet.SignatureScheme(), int64(amount))
-	check(err)
+	inputs := sourceInputs()
+	target := ownAddress()
 
-	util.PostTransaction(tx)
+	tx := BuildTransaction(inputs, func(vtxb *vtxbuilder.Builder) error {
+		return vtxb.MintColor(target, balance.ColorIOTA, int64(amount))
+	})
+	signOrWrite(tx, inputs)
 
-	fmt.Printf("Minted %d tokens of color %s\n", amount, tx.ID())
+	if unsignedOut == "" && !config.DryRun {
+		fmt.Printf("Minted %d tokens of color %s\n", amount, tx.ID())
+	}
 }
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const mnemonicEnvVar = "WWALLET_MNEMONIC"
+
+// seedEntropyLen is the size of a seed.Seed, i.e. the entropy of a 24-word
+// mnemonic.
+const seedEntropyLen = 32
+
+var force bool
+
+func exportMnemonicCmd(args []string) {
+	words, err := bip39.NewMnemonic(readSeed())
+	check(err)
+	fmt.Printf("Write down these words and keep them in a safe place.\n")
+	fmt.Printf("Anyone who knows them can spend the funds of this wallet.\n\n")
+	fmt.Printf("%s\n", words)
+}
+
+func importCmd(args []string) {
+	checkNotDerived()
+	if watchAddress != "" || watchPublicKey != "" {
+		importWatchOnly()
+		return
+	}
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s wallet import [--force] [--encrypt]\n", os.Args[0])
+		fmt.Printf("       %s wallet import --address <address>\n", os.Args[0])
+		fmt.Printf("       %s wallet import --public-key <public-key>\n", os.Args[0])
+		fmt.Printf("The mnemonic is read from %s or from the standard input.\n", mnemonicEnvVar)
+		os.Exit(1)
+	}
+	if hasSeed() && !force {
+		check(fmt.Errorf("wallet already has a seed, use --force to overwrite it"))
+	}
+	seedBytes, err := seedFromMnemonic(readMnemonic())
+	check(err)
+	writeSeed(seedBytes, encrypt)
+	fmt.Printf("Wallet seed imported\n")
+}
+
+// readMnemonic reads the word list from the environment or the standard
+// input, so that it does not show up in the process list.
+func readMnemonic() string {
+	if words, ok := os.LookupEnv(mnemonicEnvVar); ok {
+		return words
+	}
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Printf("Mnemonic: ")
+	}
+	words, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && words == "" {
+		check(fmt.Errorf("no mnemonic given: set %s or write it to the standard input", mnemonicEnvVar))
+	}
+	return words
+}
+
+func seedFromMnemonic(words string) ([]byte, error) {
+	seedBytes, err := bip39.EntropyFromMnemonic(strings.Join(strings.Fields(words), " "))
+	if err != nil {
+		return nil, err
+	}
+	if len(seedBytes) != seedEntropyLen {
+		return nil, fmt.Errorf("the mnemonic has %d words, a wallet seed needs 24", len(strings.Fields(words)))
+	}
+	return seedBytes, nil
+}
@@ -0,0 +1,53 @@
+package wallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestSeedFromMnemonicRoundTrip(t *testing.T) {
+	entropy := testSeed()
+	words, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// extra whitespace, as when pasted from paper
+	seedBytes, err := seedFromMnemonic("  " + strings.Join(strings.Fields(words), "\n ") + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(seedBytes, entropy) {
+		t.Fatalf("seed mismatch: %x != %x", seedBytes, entropy)
+	}
+}
+
+func TestSeedFromMnemonicRejectsShortMnemonics(t *testing.T) {
+	for _, n := range []int{16, 20, 24, 28} {
+		words, err := bip39.NewMnemonic(testSeed()[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := seedFromMnemonic(words); err == nil {
+			t.Errorf("%d-word mnemonic accepted", len(strings.Fields(words)))
+		}
+	}
+}
+
+func TestSeedFromMnemonicRejectsBadChecksum(t *testing.T) {
+	words, err := bip39.NewMnemonic(testSeed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(words)
+	if fields[len(fields)-1] == "abandon" {
+		fields[len(fields)-1] = "ability"
+	} else {
+		fields[len(fields)-1] = "abandon"
+	}
+	if _, err := seedFromMnemonic(strings.Join(fields, " ")); err == nil {
+		t.Fatal("mnemonic with a bad checksum accepted")
+	}
+}
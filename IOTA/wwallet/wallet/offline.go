@@ -0,0 +1,151 @@
+package wallet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/policy"
+	"wasp/tools/wwallet/txinfo"
+	clientutil "wasp/tools/wwallet/util"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	valuetransaction "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// signSearchLimit is the number of seed indices scanned by `sign` to find the
+// key pairs of the input addresses.
+const signSearchLimit = 1000
+
+var unsignedOut string
+var fromAddress string
+
+// sourceInputs returns the outputs to spend from. When building an unsigned
+// transaction with --from, the seed is not needed at all.
+func sourceInputs() []*AccountAddress {
+	if fromAddress == "" {
+		return Load().Inputs()
+	}
+	if unsignedOut == "" {
+		check(fmt.Errorf("--from can only be used together with --unsigned-out"))
+	}
+	addr := ownAddress()
+	return []*AccountAddress{{Address: addr, Outputs: fetchOutputs(addr)}}
+}
+
+func ownAddress() address.Address {
+	if fromAddress == "" {
+		return Load().Address()
+	}
+	addr, err := config.ResolveAddress(fromAddress)
+	check(err)
+	return addr
+}
+
+// signOrWrite either signs and posts the transaction, or writes it unsigned
+// to the file given with --unsigned-out, or only shows it with --dry-run.
+func signOrWrite(tx *valuetransaction.Transaction, inputs []*AccountAddress) {
+	signOrWriteTo(tx, inputs, unsignedOut)
+}
+
+// signOrWriteTo is signOrWrite with the unsigned transaction written to out
+// instead of --unsigned-out.
+func signOrWriteTo(tx *valuetransaction.Transaction, inputs []*AccountAddress, out string) {
+	if config.DryRun {
+		txinfo.Dump(tx)
+		policy.Report(tx.EssenceBytes(), PolicyOwner())
+		fmt.Printf("%s\n", config.ErrDryRun)
+		return
+	}
+	if out != "" {
+		check(ioutil.WriteFile(out, tx.Bytes(), 0644))
+		// the inputs stay reserved for the transaction until it is signed
+		// and broadcast, or the reservation expires
+		config.KeepReservation(tx)
+		txinfo.Dump(tx)
+		fmt.Printf("Unsigned transaction written to %s\n", out)
+		return
+	}
+	Load().SignInputs(tx, inputs)
+	clientutil.PostTransaction(tx)
+}
+
+func readTransactionFile(path string) *valuetransaction.Transaction {
+	b, err := ioutil.ReadFile(path)
+	check(err)
+	tx, _, err := valuetransaction.FromBytes(b)
+	check(err)
+	return tx
+}
+
+func signCmd(args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		fmt.Printf("Usage: %s sign <unsigned-tx-file> [signed-tx-file]\n", os.Args[0])
+		os.Exit(1)
+	}
+	out := args[0] + ".signed"
+	if len(args) == 2 {
+		out = args[1]
+	}
+
+	if config.DryRun {
+		// with --dry-run the wallet only has preview signatures
+		check(fmt.Errorf("sign never posts the transaction, run it without --dry-run"))
+	}
+
+	tx := readTransactionFile(args[0])
+	txinfo.Dump(tx)
+
+	wallet := Load()
+	pending := make(map[address.Address]bool)
+	tx.Inputs().ForEach(func(outputID valuetransaction.OutputID) bool {
+		pending[outputID.Address()] = true
+		return true
+	})
+	for index := uint64(0); index < signSearchLimit && len(pending) > 0; index++ {
+		if !wallet.usable(index) {
+			continue
+		}
+		addr := wallet.AddressAt(index)
+		if pending[addr] {
+			tx.Sign(wallet.SignatureSchemeAt(index))
+			delete(pending, addr)
+		}
+	}
+	for addr := range pending {
+		check(fmt.Errorf("no key pair for input address %s in the first %d seed indices", addr, signSearchLimit))
+	}
+	if !tx.SignaturesValid() {
+		check(fmt.Errorf("invalid signatures"))
+	}
+
+	check(ioutil.WriteFile(out, tx.Bytes(), 0644))
+	fmt.Printf("Signed transaction %s written to %s\n", tx.ID(), out)
+}
+
+func broadcastCmd(args []string) {
+	if len(args) != 1 {
+		fmt.Printf("Usage: %s broadcast <signed-tx-file>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	tx := readTransactionFile(args[0])
+	if len(tx.Signatures()) == 0 || !tx.SignaturesValid() {
+		check(fmt.Errorf("transaction %s is not signed or has invalid signatures", tx.ID()))
+	}
+	checkUnspent(tx)
+
+	clientutil.PostTransaction(tx)
+	fmt.Printf("Posted transaction %s\n", tx.ID())
+}
+
+// checkUnspent fails if any input of the transaction is no longer among the
+// confirmed unspent outputs of its address.
+func checkUnspent(tx *valuetransaction.Transaction) {
+	spent, err := clientutil.SpentInputs(tx)
+	check(err)
+	if len(spent) > 0 {
+		check(fmt.Errorf("input %s is already spent or not confirmed", spent[0]))
+	}
+}
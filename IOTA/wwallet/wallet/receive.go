@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"wasp/packages/util"
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/mdp/qrterminal"
+	"github.com/skip2/go-qrcode"
+)
+
+const paymentURIScheme = "iota"
+
+var receiveAmount int64
+var receiveMemo string
+var receivePNG string
+
+// PaymentRequest is encoded as iota:<address>?amount=<n>&color=<color>&memo=<text>
+type PaymentRequest struct {
+	Address address.Address
+	Color   balance.Color
+	Amount  int64
+	Memo    string
+}
+
+func (p *PaymentRequest) URI() string {
+	q := url.Values{}
+	if p.Amount > 0 {
+		q.Set("amount", strconv.FormatInt(p.Amount, 10))
+	}
+	if p.Color != balance.ColorIOTA {
+		q.Set("color", p.Color.String())
+	}
+	if p.Memo != "" {
+		q.Set("memo", p.Memo)
+	}
+	u := url.URL{Scheme: paymentURIScheme, Opaque: p.Address.String(), RawQuery: q.Encode()}
+	return u.String()
+}
+
+func IsPaymentURI(s string) bool {
+	return strings.HasPrefix(s, paymentURIScheme+":")
+}
+
+func ParsePaymentURI(s string) (*PaymentRequest, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != paymentURIScheme || u.Opaque == "" {
+		return nil, fmt.Errorf("not a payment URI: %s", s)
+	}
+	p := &PaymentRequest{Color: balance.ColorIOTA}
+	if p.Address, err = address.FromBase58(u.Opaque); err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	if s := q.Get("color"); s != "" {
+		if p.Color, err = util.ColorFromString(s); err != nil {
+			return nil, err
+		}
+	}
+	if s := q.Get("amount"); s != "" {
+		if p.Amount, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, err
+		}
+		if p.Amount < 0 {
+			return nil, fmt.Errorf("invalid amount in payment URI: %d", p.Amount)
+		}
+	}
+	p.Memo = q.Get("memo")
+	return p, nil
+}
+
+func receiveCmd(args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s wallet receive [--amount <n>] [--color <color>] [--memo <text>] [--png <file>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	p := &PaymentRequest{
+		Address: Load().Address(),
+		Color:   balance.ColorIOTA,
+		Amount:  receiveAmount,
+		Memo:    receiveMemo,
+	}
+	if colorFilter != "" {
+		p.Color = *decodeColor(colorFilter)
+	}
+	uri := p.URI()
+
+	if receivePNG != "" {
+		check(qrcode.WriteFile(uri, qrcode.Medium, 256, receivePNG))
+		fmt.Printf("QR code written to %s\n", receivePNG)
+	} else {
+		qrterminal.GenerateHalfBlock(uri, qrterminal.L, os.Stdout)
+	}
+	fmt.Printf("%s\n", uri)
+}
+
+// sendFundsArgs accepts either <target-address|alias> <color> <amount> or a
+// payment URI.
+func sendFundsArgs(args []string) (address.Address, *balance.Color, int64) {
+	if len(args) == 1 && IsPaymentURI(args[0]) {
+		p, err := ParsePaymentURI(args[0])
+		check(err)
+		if p.Amount <= 0 {
+			check(fmt.Errorf("the payment URI has no amount"))
+		}
+		if p.Memo != "" {
+			fmt.Printf("Memo: %s\n", p.Memo)
+		}
+		return p.Address, &p.Color, p.Amount
+	}
+	if len(args) < 3 {
+		fmt.Printf("Usage: %s send-funds <target-address|alias> <color> <amount>\n", os.Args[0])
+		fmt.Printf("       %s send-funds <payment-uri>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	targetAddress, err := config.ResolveAddress(args[0])
+	check(err)
+
+	color := decodeColor(args[1])
+
+	amount, err := strconv.Atoi(args[2])
+	check(err)
+
+	return targetAddress, color, int64(amount)
+}
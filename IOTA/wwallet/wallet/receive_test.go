@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+func TestPaymentURIRoundTrip(t *testing.T) {
+	for name, p := range map[string]*PaymentRequest{
+		"address only": {Address: testAddress(0), Color: balance.ColorIOTA},
+		"amount":       {Address: testAddress(0), Color: balance.ColorIOTA, Amount: 15},
+		"colored":      {Address: testAddress(1), Color: balance.Color{1, 2, 3}, Amount: 2},
+		"memo":         {Address: testAddress(0), Color: balance.ColorIOTA, Amount: 1, Memo: "bus ticket & more?"},
+	} {
+		got, err := ParsePaymentURI(p.URI())
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if *got != *p {
+			t.Errorf("%s: parsed %+v, want %+v", name, got, p)
+		}
+	}
+}
+
+func TestParsePaymentURI(t *testing.T) {
+	addr := testAddress(0).String()
+	for uri, want := range map[string]*PaymentRequest{
+		"iota:" + addr:                          {Address: testAddress(0), Color: balance.ColorIOTA},
+		"iota:" + addr + "?amount=100":          {Address: testAddress(0), Color: balance.ColorIOTA, Amount: 100},
+		"iota:" + addr + "?color=IOTA&amount=1": {Address: testAddress(0), Color: balance.ColorIOTA, Amount: 1},
+		"iota:" + addr + "?memo=a%20b":          {Address: testAddress(0), Color: balance.ColorIOTA, Memo: "a b"},
+		"iota:" + addr + "?unknown=x":           {Address: testAddress(0), Color: balance.ColorIOTA},
+	} {
+		got, err := ParsePaymentURI(uri)
+		if err != nil {
+			t.Errorf("%s: %v", uri, err)
+			continue
+		}
+		if *got != *want {
+			t.Errorf("%s: parsed %+v, want %+v", uri, got, want)
+		}
+	}
+}
+
+func TestParsePaymentURIErrors(t *testing.T) {
+	addr := testAddress(0).String()
+	for name, uri := range map[string]string{
+		"other scheme":    "bitcoin:" + addr,
+		"no address":      "iota:",
+		"hierarchical":    "iota://" + addr,
+		"invalid address": "iota:nobody",
+		"invalid color":   "iota:" + addr + "?color=not-a-color",
+		"invalid amount":  "iota:" + addr + "?amount=ten",
+		"negative amount": "iota:" + addr + "?amount=-5",
+		"not a URI":       "%zz",
+	} {
+		if _, err := ParsePaymentURI(uri); err == nil {
+			t.Errorf("%s: %s accepted", name, uri)
+		}
+	}
+}
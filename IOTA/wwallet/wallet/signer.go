@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// Signer holds (or has access to) the key pairs of a wallet, indexed by seed
+// index.
+type Signer interface {
+	Address(index uint64) address.Address
+	// PublicKey returns nil if the public key is not known.
+	PublicKey(index uint64) *ed25519.PublicKey
+	SignatureScheme(index uint64) signaturescheme.SignatureScheme
+}
+
+// seedSigner signs in-process with the seed read from the config file. The
+// seed is only read when it is needed: an encrypted seed is decrypted to
+// sign, or to derive an address that is not in the address cache yet.
+type seedSigner struct {
+	seed *seed.Seed
+	// cache is nil for a plaintext seed.
+	cache *addressCache
+}
+
+func newSeedSigner() *seedSigner {
+	s := &seedSigner{}
+	if ks := readKeystore(); ks != nil {
+		s.cache = readAddressCache(ks)
+	}
+	return s
+}
+
+// keys returns the seed, asking for the passphrase if it is encrypted.
+func (s *seedSigner) keys() *seed.Seed {
+	if s.seed == nil {
+		s.seed = seed.NewSeed(readSeed())
+	}
+	return s.seed
+}
+
+func (s *seedSigner) public(index uint64) *publicKeys {
+	if s.cache != nil {
+		if k, ok := s.cache.keys[index]; ok {
+			return k
+		}
+	}
+	k := &publicKeys{s.keys().Address(index).Address, s.keys().KeyPair(index).PublicKey}
+	if s.cache != nil {
+		s.cache.put(index, k)
+	}
+	return k
+}
+
+func (s *seedSigner) Address(index uint64) address.Address {
+	return s.public(index).address
+}
+
+func (s *seedSigner) PublicKey(index uint64) *ed25519.PublicKey {
+	return &s.public(index).publicKey
+}
+
+func (s *seedSigner) SignatureScheme(index uint64) signaturescheme.SignatureScheme {
+	return signaturescheme.ED25519(*s.keys().KeyPair(index))
+}
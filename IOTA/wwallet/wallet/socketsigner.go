@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/txinfo"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/spf13/viper"
+)
+
+// defaultSignerSocket is in the runtime directory, which signer serve
+// creates accessible only by the user.
+func defaultSignerSocket() string {
+	return filepath.Join(config.RuntimeDir(), "signer.sock")
+}
+
+const (
+	signerOpAddress = "address"
+	signerOpSign    = "sign"
+)
+
+// The signer protocol: one JSON request and one JSON response per
+// connection to the Unix socket served by `signer serve`.
+type signerRequest struct {
+	Op      string `json:"op"`
+	Index   uint64 `json:"index"`
+	Essence []byte `json:"essence,omitempty"`
+}
+
+type signerResponse struct {
+	Address   string `json:"address,omitempty"`
+	PublicKey string `json:"publickey,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func signerSocket() string {
+	return walletSection().Signer.Socket
+}
+
+// socketSigner delegates signing to a `signer serve` process, so that the
+// seed never needs to be known by this one.
+type socketSigner struct {
+	path       string
+	addresses  map[uint64]address.Address
+	publicKeys map[uint64]*ed25519.PublicKey
+}
+
+func (s *socketSigner) call(req *signerRequest) *signerResponse {
+	conn, err := net.Dial("unix", s.path)
+	if err != nil {
+		check(fmt.Errorf("cannot reach the signer at %s: %v", s.path, err))
+	}
+	defer conn.Close()
+	check(json.NewEncoder(conn).Encode(req))
+	resp := &signerResponse{}
+	check(json.NewDecoder(conn).Decode(resp))
+	if resp.Error != "" {
+		check(fmt.Errorf("signer: %s", resp.Error))
+	}
+	return resp
+}
+
+func (s *socketSigner) Address(index uint64) address.Address {
+	s.fetchAddress(index)
+	return s.addresses[index]
+}
+
+func (s *socketSigner) PublicKey(index uint64) *ed25519.PublicKey {
+	s.fetchAddress(index)
+	return s.publicKeys[index]
+}
+
+func (s *socketSigner) fetchAddress(index uint64) {
+	if _, ok := s.addresses[index]; ok {
+		return
+	}
+	resp := s.call(&signerRequest{Op: signerOpAddress, Index: index})
+	addr, err := address.FromBase58(resp.Address)
+	check(err)
+	if s.addresses == nil {
+		s.addresses = make(map[uint64]address.Address)
+		s.publicKeys = make(map[uint64]*ed25519.PublicKey)
+	}
+	s.addresses[index] = addr
+	if resp.PublicKey != "" {
+		s.publicKeys[index] = decodePublicKey(resp.PublicKey)
+	}
+}
+
+func (s *socketSigner) SignatureScheme(index uint64) signaturescheme.SignatureScheme {
+	return &socketSignatureScheme{s, index}
+}
+
+type socketSignatureScheme struct {
+	signer *socketSigner
+	index  uint64
+}
+
+func (s *socketSignatureScheme) Version() byte {
+	return address.VersionED25519
+}
+
+func (s *socketSignatureScheme) Address() address.Address {
+	return s.signer.Address(s.index)
+}
+
+func (s *socketSignatureScheme) Sign(data []byte) signaturescheme.Signature {
+	resp := s.signer.call(&signerRequest{Op: signerOpSign, Index: s.index, Essence: data})
+	sig, _, err := signaturescheme.Ed25519SignatureFromBytes(resp.Signature)
+	check(err)
+	return sig
+}
+
+var signerSubcmds = map[string]func([]string){
+	"serve":   signerServeCmd,
+	"use":     signerUseCmd,
+	"disable": signerDisableCmd,
+}
+
+func signerCmd(args []string) {
+	if len(args) < 1 {
+		signerUsage()
+	}
+	subcmd, ok := signerSubcmds[args[0]]
+	if !ok {
+		signerUsage()
+	}
+	subcmd(args[1:])
+}
+
+func signerUsage() {
+	cmdNames := make([]string, 0)
+	for k := range signerSubcmds {
+		cmdNames = append(cmdNames, k)
+	}
+
+	fmt.Printf("Usage: %s signer [%s]\n", os.Args[0], strings.Join(cmdNames, "|"))
+	os.Exit(1)
+}
+
+func signerUseCmd(args []string) {
+	path := defaultSignerSocket()
+	if len(args) > 0 {
+		path = args[0]
+	}
+	path, err := filepath.Abs(path)
+	check(err)
+	viper.Set(walletConfigVar("signer.socket"), path)
+	check(config.WriteConfig())
+}
+
+func signerDisableCmd(args []string) {
+	config.Unset(walletConfigVar("signer"))
+}
+
+// signerServeCmd holds the seed and signs on behalf of other wwallet
+// processes, asking for approval of every transaction on the terminal.
+func signerServeCmd(args []string) {
+	path := defaultSignerSocket()
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	wallet := Load()
+	s, ok := wallet.signer.(*seedSigner)
+	if !ok {
+		check(fmt.Errorf("signer serve needs a wallet with a seed"))
+	}
+	// ask for the passphrase now rather than on the first request
+	s.keys()
+
+	checkPrivateDir(filepath.Dir(path))
+	_ = os.Remove(path)
+	l, err := net.Listen("unix", path)
+	check(err)
+	defer l.Close()
+	fmt.Printf("Signer listening on %s\n", path)
+
+	stdin := bufio.NewReader(os.Stdin)
+	for {
+		conn, err := l.Accept()
+		check(err)
+		serveSignerConn(wallet, conn, stdin)
+	}
+}
+
+// checkPrivateDir creates the directory of the socket if needed, and makes
+// sure that other users cannot reach the socket through it, since the
+// permissions of the socket itself can only be set after it is created.
+func checkPrivateDir(dir string) {
+	check(os.MkdirAll(dir, 0700))
+	info, err := os.Stat(dir)
+	check(err)
+	if info.Mode().Perm()&0077 != 0 {
+		check(fmt.Errorf("%s is accessible by other users; the signer socket must be in a private directory (mode 0700), such as %s",
+			dir, filepath.Dir(defaultSignerSocket())))
+	}
+}
+
+func serveSignerConn(wallet *Wallet, conn net.Conn, stdin *bufio.Reader) {
+	defer conn.Close()
+
+	req := &signerRequest{}
+	resp := &signerResponse{}
+	if err := json.NewDecoder(conn).Decode(req); err != nil {
+		return
+	}
+	switch req.Op {
+	case signerOpAddress:
+		resp.Address = wallet.rawAddressAt(req.Index).String()
+		resp.PublicKey = wallet.rawPublicKeyAt(req.Index).String()
+	case signerOpSign:
+		if approve(wallet, req, stdin) {
+			resp.Signature = wallet.rawSignatureSchemeAt(req.Index).Sign(req.Essence).Bytes()
+		} else {
+			resp.Error = "rejected by the operator"
+		}
+	default:
+		resp.Error = fmt.Sprintf("unknown op %s", req.Op)
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+func approve(wallet *Wallet, req *signerRequest, stdin *bufio.Reader) bool {
+	fmt.Printf("\nSignature requested for address index %d (%s)\n", req.Index, wallet.rawAddressAt(req.Index))
+	txinfo.DumpEssence(req.Essence)
+	fmt.Printf("Approve? [y/N] ")
+	answer, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(strings.ToLower(answer)) == "y"
+}
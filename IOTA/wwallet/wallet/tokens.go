@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"wasp/packages/txutil"
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+const tokenCacheTTL = 1 * time.Hour
+
+// TokenInfo is the registry metadata of a colored token supply.
+type TokenInfo struct {
+	Description string
+	MintedBy    string
+	Supply      int64
+	Created     int64
+}
+
+// TokenRegistry is the TokenRegistry SC, as used by the wallet commands.
+type TokenRegistry interface {
+	// Address returns the address of the registry, or nil if it is not
+	// configured.
+	Address() *address.Address
+	// Lookup returns the metadata of color, or nil if it is not registered.
+	Lookup(color balance.Color) (*TokenInfo, error)
+}
+
+type tokenCacheEntry struct {
+	Info    *TokenInfo `json:",omitempty"`
+	Fetched time.Time
+}
+
+func tokensCmd(registry TokenRegistry, args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s wallet tokens\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	byColor, _ := txutil.OutputBalancesByColor(MergeOutputs(Load().Inputs()))
+	colors := make([]balance.Color, 0, len(byColor))
+	for color := range byColor {
+		if color != balance.ColorIOTA {
+			colors = append(colors, color)
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool { return colors[i].String() < colors[j].String() })
+
+	fmt.Printf("IOTA: %d\n", byColor[balance.ColorIOTA])
+	if len(colors) == 0 {
+		fmt.Printf("No colored tokens\n")
+		return
+	}
+
+	cache := readTokenCache()
+	var lookupErr error
+	for _, color := range colors {
+		fmt.Printf("%s: %d\n", color, byColor[color])
+		info, err := lookupToken(cache, registry, color)
+		switch {
+		case err != nil:
+			lookupErr = err
+			fmt.Printf("  (TokenRegistry not available)\n")
+		case info == nil:
+			fmt.Printf("  (not registered)\n")
+		default:
+			fmt.Printf("  Description: %s\n", info.Description)
+			fmt.Printf("  Minted by:   %s\n", info.MintedBy)
+			fmt.Printf("  Supply:      %d\n", info.Supply)
+			if burned := config.BurnedSupply(color); burned > 0 {
+				fmt.Printf("  Circulating: %d (%d burned by this wallet)\n", info.Supply-burned, burned)
+			}
+			fmt.Printf("  Created:     %s\n", time.Unix(0, info.Created).UTC())
+		}
+	}
+	writeTokenCache(cache)
+	if lookupErr != nil {
+		fmt.Fprintf(os.Stderr, "warning: TokenRegistry query failed: %v\n", lookupErr)
+	}
+}
+
+// lookupToken returns the registry metadata of color, from the cache if it is
+// fresh enough. Unregistered colors are not cached, since they may be
+// registered at any time.
+func lookupToken(cache map[string]*tokenCacheEntry, registry TokenRegistry, color balance.Color) (*TokenInfo, error) {
+	registryAddress := registry.Address()
+	if registryAddress == nil {
+		return nil, fmt.Errorf("TokenRegistry address is not configured")
+	}
+	key := tokenCacheKey(registryAddress, color)
+	if e, found := cache[key]; found && time.Since(e.Fetched) < tokenCacheTTL {
+		return e.Info, nil
+	}
+	info, err := registry.Lookup(color)
+	if err != nil || info == nil {
+		return info, err
+	}
+	cache[key] = &tokenCacheEntry{Info: info, Fetched: time.Now()}
+	return info, nil
+}
+
+// tokenCacheKey identifies the metadata of a color in a given registry of the
+// selected profile.
+func tokenCacheKey(registryAddress *address.Address, color balance.Color) string {
+	return config.Profile() + "/" + registryAddress.String() + "/" + color.String()
+}
+
+// forgetToken drops the cached metadata of color, after its supply changed.
+func forgetToken(registry TokenRegistry, color balance.Color) {
+	registryAddress := registry.Address()
+	if registryAddress == nil {
+		return
+	}
+	cache := readTokenCache()
+	key := tokenCacheKey(registryAddress, color)
+	if _, ok := cache[key]; ok {
+		delete(cache, key)
+		writeTokenCache(cache)
+	}
+}
+
+func readTokenCache() map[string]*tokenCacheEntry {
+	cache := make(map[string]*tokenCacheEntry)
+	b, err := ioutil.ReadFile(config.TokenCachePath())
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(b, &cache); err != nil {
+		return make(map[string]*tokenCacheEntry)
+	}
+	return cache
+}
+
+func writeTokenCache(cache map[string]*tokenCacheEntry) {
+	b, err := json.MarshalIndent(cache, "", "  ")
+	check(err)
+	if err := ioutil.WriteFile(config.TokenCachePath(), b, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not write the token cache: %v\n", err)
+	}
+}
@@ -3,12 +3,13 @@ package wallet
 import (
 	"fmt"
 
+	"wasp/tools/wwallet/config"
+	"wasp/tools/wwallet/policy"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
-	"github.com/mr-tron/base58"
-	"github.com/spf13/viper"
 )
 
 type WalletConfig struct {
@@ -16,35 +17,157 @@ type WalletConfig struct {
 }
 
 type Wallet struct {
-	seed *seed.Seed
+	signer Signer
+	// offset is the index on the default seed of a derived identity, which
+	// only has that address.
+	offset  uint64
+	derived bool
+	// claimed are the indices of the default seed used by derived
+	// identities, which the default identity leaves alone.
+	claimed map[uint64]string
+	// owned caches the addresses of the identity, see Owns.
+	owned map[string]bool
 }
 
+var encrypt bool
+
 func initCmd(args []string) {
+	checkNotDerived()
 	seed := seed.NewSeed().Bytes()
-	viper.Set("wallet.seed", base58.Encode(seed))
-	check(viper.WriteConfig())
+	writeSeed(seed, encrypt)
 }
 
+var loaded *Wallet
+
 func Load() *Wallet {
-	seedb58 := viper.GetString("wallet.seed")
-	if len(seedb58) == 0 {
-		check(fmt.Errorf("call `init` first"))
+	if loaded != nil {
+		return loaded
+	}
+	offset, derived := derivedIndex(IdentityName())
+	loaded = &Wallet{offset: offset, derived: derived}
+	if IdentityName() == defaultIdentity {
+		loaded.claimed = claimedIndices()
+	}
+	if socket := signerSocket(); socket != "" {
+		loaded.signer = &socketSigner{path: socket}
+		return loaded
 	}
-	seedBytes, err := base58.Decode(seedb58)
-	check(err)
-	return &Wallet{seed.NewSeed(seedBytes)}
+	if watch := readWatchAddress(); watch != nil && !hasSeed() {
+		loaded.signer = &watchOnlySigner{*watch, readWatchPublicKey()}
+		return loaded
+	}
+	loaded.signer = newSeedSigner()
+	return loaded
 }
 
 var addressIndex int
 
 func (w *Wallet) KeyPair() *ed25519.KeyPair {
-	return w.seed.KeyPair(uint64(addressIndex))
+	return w.KeyPairAt(uint64(addressIndex))
 }
 
 func (w *Wallet) Address() address.Address {
-	return w.seed.Address(uint64(addressIndex)).Address
+	return w.AddressAt(uint64(addressIndex))
 }
 
 func (w *Wallet) SignatureScheme() signaturescheme.SignatureScheme {
-	return signaturescheme.ED25519(*w.KeyPair())
+	return w.SignatureSchemeAt(uint64(addressIndex))
+}
+
+// KeyPairAt is only available when the seed is held by this process.
+func (w *Wallet) KeyPairAt(index uint64) *ed25519.KeyPair {
+	s, ok := w.signer.(*seedSigner)
+	if !ok {
+		check(fmt.Errorf("the private keys of this wallet are not held by wwallet"))
+	}
+	w.checkIndex(index)
+	return s.keys().KeyPair(w.offset + index)
+}
+
+func (w *Wallet) AddressAt(index uint64) address.Address {
+	w.checkIndex(index)
+	return w.signer.Address(w.offset + index)
+}
+
+// PublicKeyAt returns nil if the public key is not known, as for a
+// watch-only address imported without it.
+func (w *Wallet) PublicKeyAt(index uint64) *ed25519.PublicKey {
+	w.checkIndex(index)
+	return w.signer.PublicKey(w.offset + index)
+}
+
+// usable tells whether the address index belongs to the current identity.
+func (w *Wallet) usable(index uint64) bool {
+	if w.derived {
+		return index == 0
+	}
+	_, ok := w.claimed[index]
+	return !ok
+}
+
+func (w *Wallet) checkIndex(index uint64) {
+	if w.usable(index) {
+		return
+	}
+	if w.derived {
+		check(fmt.Errorf("identity %s only has one address, index %d of the default seed", IdentityName(), w.offset))
+	}
+	check(fmt.Errorf("address index %d is used by identity %s, select it with --as %s", index, w.claimed[index], w.claimed[index]))
+}
+
+// SignatureSchemeAt returns a signature scheme that enforces the spending
+// policy before signing. With --dry-run it only reports the policy verdict.
+func (w *Wallet) SignatureSchemeAt(index uint64) signaturescheme.SignatureScheme {
+	w.checkIndex(index)
+	if config.DryRun {
+		return policy.Preview(w.AddressAt(index), w.Owns)
+	}
+	if w.IsWatchOnly() {
+		return w.signer.SignatureScheme(w.offset + index)
+	}
+	return policy.Wrap(w.rawSignatureSchemeAt(index), w.Owns)
+}
+
+// Owns tells whether a base58 address belongs to the current identity. The
+// identities sharing the default seed are told apart by the indices they use,
+// within the first signSearchLimit ones.
+func (w *Wallet) Owns(b58 string) bool {
+	if w.owned == nil {
+		limit := uint64(signSearchLimit)
+		if w.derived || w.IsWatchOnly() {
+			limit = 1
+		}
+		w.owned = make(map[string]bool)
+		for index := uint64(0); index < limit; index++ {
+			if w.usable(index) {
+				w.owned[w.AddressAt(index).String()] = true
+			}
+		}
+	}
+	return w.owned[b58]
+}
+
+// PolicyOwner returns the Owner of the spending policy: the current identity,
+// or only the --from address when building without the seed.
+func PolicyOwner() policy.Owner {
+	if fromAddress != "" {
+		from := ownAddress().String()
+		return func(b58 string) bool { return b58 == from }
+	}
+	return Load().Owns
+}
+
+// rawSignatureSchemeAt does not enforce the spending policy. It is only used
+// by `signer serve`, since the policy is enforced by the requesting process,
+// which also checks that the index belongs to its identity.
+func (w *Wallet) rawSignatureSchemeAt(index uint64) signaturescheme.SignatureScheme {
+	return w.signer.SignatureScheme(w.offset + index)
+}
+
+func (w *Wallet) rawAddressAt(index uint64) address.Address {
+	return w.signer.Address(w.offset + index)
+}
+
+func (w *Wallet) rawPublicKeyAt(index uint64) *ed25519.PublicKey {
+	return w.signer.PublicKey(w.offset + index)
 }
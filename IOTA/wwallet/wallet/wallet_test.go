@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"testing"
+
+	"wasp/tools/wwallet/journal"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+)
+
+func testWallet(offset uint64, derived bool, claimed map[uint64]string) *Wallet {
+	return &Wallet{
+		signer:  &seedSigner{seed: seed.NewSeed(testSeed())},
+		offset:  offset,
+		derived: derived,
+		claimed: claimed,
+	}
+}
+
+func TestOwns(t *testing.T) {
+	def := testWallet(0, false, map[uint64]string{3: "inspector"})
+	inspector := testWallet(3, true, nil)
+
+	for _, tc := range []struct {
+		wallet *Wallet
+		index  uint64
+		want   bool
+	}{
+		{def, 0, true},
+		{def, 7, true},
+		{def, 3, false},
+		{inspector, 3, true},
+		{inspector, 0, false},
+		{inspector, 4, false},
+	} {
+		if got := tc.wallet.Owns(testAddress(tc.index).String()); got != tc.want {
+			t.Errorf("offset %d: Owns(index %d) = %v, want %v", tc.wallet.offset, tc.index, got, tc.want)
+		}
+	}
+}
+
+func TestPostedBy(t *testing.T) {
+	entries := []*journal.Entry{
+		{TxID: "default", Inputs: []string{testAddress(0).String()}},
+		{TxID: "inspector", Inputs: []string{testAddress(3).String()}},
+		{TxID: "both", Inputs: []string{testAddress(3).String(), testAddress(1).String()}},
+	}
+	got := postedBy(testWallet(3, true, nil), entries)
+	if len(got) != 2 || got[0].TxID != "inspector" || got[1].TxID != "both" {
+		t.Fatalf("postedBy = %v", got)
+	}
+}
@@ -0,0 +1,159 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"wasp/packages/subscribe"
+	"wasp/packages/txutil"
+	"wasp/packages/util"
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+var untilBalance string
+var pollInterval time.Duration
+
+type balanceCondition struct {
+	color balance.Color
+	op    string
+	value int64
+}
+
+// parseBalanceCondition parses conditions like IOTA>=100 or <color><10.
+func parseBalanceCondition(s string) (*balanceCondition, error) {
+	for _, op := range []string{">=", "<=", "==", ">", "<"} {
+		i := strings.Index(s, op)
+		if i < 0 {
+			continue
+		}
+		color, err := decodeColorOrIOTA(s[:i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseInt(s[i+len(op):], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &balanceCondition{color: color, op: op, value: value}, nil
+	}
+	return nil, fmt.Errorf("invalid balance condition %q: expected <color><op><amount>, e.g. IOTA>=100", s)
+}
+
+func decodeColorOrIOTA(s string) (balance.Color, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "IOTA") {
+		return balance.ColorIOTA, nil
+	}
+	return util.ColorFromString(s)
+}
+
+func (c *balanceCondition) holds(totals map[balance.Color]int64) bool {
+	v := totals[c.color]
+	switch c.op {
+	case ">=":
+		return v >= c.value
+	case "<=":
+		return v <= c.value
+	case "==":
+		return v == c.value
+	case ">":
+		return v > c.value
+	default:
+		return v < c.value
+	}
+}
+
+func watchCmd(args []string) {
+	if len(args) != 0 {
+		fmt.Printf("Usage: %s wallet watch [--until-balance <color><op><amount>] [--poll-interval <duration>]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	var cond *balanceCondition
+	if untilBalance != "" {
+		var err error
+		cond, err = parseBalanceCondition(untilBalance)
+		check(err)
+	}
+
+	// state updates published by the wasp node trigger an immediate refresh;
+	// polling catches plain value transfers and covers the case where the
+	// nanomsg stream is not reachable
+	events := make(chan []string)
+	done := make(chan bool)
+	defer close(done)
+	if err := subscribe.Subscribe(config.WaspNanomsg(), events, done, false, "state"); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not subscribe to %s, polling only: %v\n", config.WaspNanomsg(), err)
+	}
+
+	w := Load()
+	var last map[address.Address]map[balance.Color]int64
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		current := watchedBalances(w)
+		printBalanceChanges(last, current)
+		last = current
+
+		if cond != nil && cond.holds(totalBalances(current)) {
+			fmt.Printf("Condition %s reached\n", untilBalance)
+			return
+		}
+
+		select {
+		case <-events:
+		case <-ticker.C:
+		}
+	}
+}
+
+func watchedBalances(w *Wallet) map[address.Address]map[balance.Color]int64 {
+	ret := make(map[address.Address]map[balance.Color]int64)
+	for _, a := range w.Inputs() {
+		byColor, _ := txutil.OutputBalancesByColor(a.Outputs)
+		ret[a.Address] = byColor
+	}
+	return ret
+}
+
+func totalBalances(bals map[address.Address]map[balance.Color]int64) map[balance.Color]int64 {
+	ret := make(map[balance.Color]int64)
+	for _, byColor := range bals {
+		for color, v := range byColor {
+			ret[color] += v
+		}
+	}
+	return ret
+}
+
+func printBalanceChanges(last, current map[address.Address]map[balance.Color]int64) {
+	now := time.Now().Format("2006-01-02 15:04:05")
+	if last == nil {
+		for addr, byColor := range current {
+			for color, v := range byColor {
+				fmt.Printf("%s %s %s: %d\n", now, addr, color, v)
+			}
+		}
+		return
+	}
+	for addr, byColor := range current {
+		for color, v := range byColor {
+			if old := last[addr][color]; old != v {
+				fmt.Printf("%s %s %s: %d -> %d (%+d)\n", now, addr, color, old, v, v-old)
+			}
+		}
+	}
+	for addr, byColor := range last {
+		for color, old := range byColor {
+			if _, ok := current[addr][color]; !ok && old != 0 {
+				fmt.Printf("%s %s %s: %d -> 0 (%+d)\n", now, addr, color, old, -old)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"wasp/tools/wwallet/config"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/mr-tron/base58"
+	"github.com/spf13/viper"
+)
+
+var watchAddress string
+var watchPublicKey string
+
+var errWatchOnly = fmt.Errorf("wallet is watch-only: it holds no private keys and cannot sign")
+
+// watchOnlySignatureScheme lets the SC clients be built for a watch-only
+// wallet, so that status commands work, while any attempt to sign fails.
+type watchOnlySignatureScheme struct {
+	address address.Address
+}
+
+func (s *watchOnlySignatureScheme) Version() byte {
+	return address.VersionED25519
+}
+
+func (s *watchOnlySignatureScheme) Address() address.Address {
+	return s.address
+}
+
+func (s *watchOnlySignatureScheme) Sign(data []byte) signaturescheme.Signature {
+	check(errWatchOnly)
+	return nil
+}
+
+// watchOnlySigner has a single address and no private key.
+type watchOnlySigner struct {
+	address   address.Address
+	publicKey *ed25519.PublicKey
+}
+
+func (s *watchOnlySigner) Address(index uint64) address.Address {
+	return s.address
+}
+
+func (s *watchOnlySigner) PublicKey(index uint64) *ed25519.PublicKey {
+	return s.publicKey
+}
+
+func (s *watchOnlySigner) SignatureScheme(index uint64) signaturescheme.SignatureScheme {
+	return &watchOnlySignatureScheme{s.address}
+}
+
+func (w *Wallet) IsWatchOnly() bool {
+	_, ok := w.signer.(*watchOnlySigner)
+	return ok
+}
+
+func readWatchPublicKey() *ed25519.PublicKey {
+	b58 := walletSection().Watch.PublicKey
+	if b58 == "" {
+		return nil
+	}
+	return decodePublicKey(b58)
+}
+
+func decodePublicKey(b58 string) *ed25519.PublicKey {
+	b, err := base58.Decode(b58)
+	check(err)
+	pubKey, _, err := ed25519.PublicKeyFromBytes(b)
+	check(err)
+	return &pubKey
+}
+
+func readWatchAddress() *address.Address {
+	b58 := walletSection().Watch.Address
+	if b58 == "" {
+		return nil
+	}
+	addr, err := address.FromBase58(b58)
+	check(err)
+	return &addr
+}
+
+// importWatchOnly stores a watch-only address in the current identity. It
+// never replaces a seed: watch-only addresses live in identities of their own.
+func importWatchOnly() {
+	if hasSeed() {
+		check(fmt.Errorf("identity %s holds a seed; import the address as a separate identity with `%s identity create <name> --address <address>`",
+			IdentityName(), os.Args[0]))
+	}
+	var addr address.Address
+	if watchPublicKey != "" {
+		addr = address.FromED25519PubKey(*decodePublicKey(watchPublicKey))
+	} else {
+		var err error
+		addr, err = address.FromBase58(watchAddress)
+		check(err)
+	}
+	viper.Set(walletConfigVar("watch.address"), addr.String())
+	viper.Set(walletConfigVar("watch.publickey"), watchPublicKey)
+	check(config.WriteConfig())
+	fmt.Printf("Watch-only address imported into identity %s: %s\n", IdentityName(), addr)
+}